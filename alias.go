@@ -0,0 +1,72 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"context"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+type aliasParser struct {
+	Parser
+	aliases []string
+}
+
+func (p *aliasParser) describe() field {
+	f := describe(p.Parser)
+	f.constraints = append(slices.Clip(f.constraints), "also accepted as "+strings.Join(p.aliases, ", "))
+	return f
+}
+
+func (p *aliasParser) destinations() []any {
+	return destinations(p.Parser)
+}
+
+func (p *aliasParser) parseForm(ctx context.Context, name string, data url.Values, o *options) error {
+	var merged []string
+	for _, n := range p.names(name) {
+		merged = append(merged, data[n]...)
+	}
+
+	view := url.Values{name: merged}
+	if _, ok := p.Parser.(formParser); ok {
+		view = maps.Clone(data)
+		view[name] = merged
+	}
+
+	return parseField(ctx, name, p.Parser, view, o)
+}
+
+func (p *aliasParser) claims(name, key string) bool {
+	if fp, ok := p.Parser.(formParser); ok && fp.claims(name, key) {
+		return true
+	}
+	return slices.Contains(p.names(name), key)
+}
+
+// names returns the schema field name followed by each unique alias.
+func (p *aliasParser) names(name string) []string {
+	names := []string{name}
+	for _, alias := range p.aliases {
+		if !slices.Contains(names, alias) {
+			names = append(names, alias)
+		}
+	}
+	return names
+}
+
+// Alias wraps p so that it also reads the values of form fields submitted under
+// any of the given alternate names, e.g. to support legacy clients while a
+// field is being renamed. Values submitted under the schema field name and its
+// aliases are combined, so submitting more than one of them is treated the
+// same as submitting the field more than once.
+func Alias(p Parser, names ...string) Parser {
+	return &aliasParser{
+		Parser:  p,
+		aliases: names,
+	}
+}
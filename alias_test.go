@@ -0,0 +1,74 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_Alias(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"email", "e-mail", "emailAddress"} {
+		data := url.Values{
+			name: []string{"bob@example.com"},
+		}
+
+		var email string
+		err := ParseValues(data, Schema{
+			"email": Alias(String(&email), "email", "e-mail", "emailAddress"),
+		}, Strict())
+		must.NoError(t, err, must.Sprint(name))
+		must.Eq(t, "bob@example.com", email, must.Sprint(name))
+	}
+}
+
+func Test_Parse_Alias_conflict(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"email":  []string{"bob@example.com"},
+		"e-mail": []string{"alice@example.com"},
+	}
+
+	var email string
+	err := ParseValues(data, Schema{
+		"email": Alias(String(&email), "e-mail"),
+	})
+	must.ErrorIs(t, err, ErrMulitpleValues)
+
+	err = ParseValues(data, Schema{
+		"email": Alias(String(&email), "e-mail"),
+	}, MultipleValues(TakeLast))
+	must.NoError(t, err)
+	must.Eq(t, "alice@example.com", email)
+}
+
+func Test_Parse_Alias_missing(t *testing.T) {
+	t.Parallel()
+
+	var email string
+	err := ParseValues(url.Values{}, Schema{
+		"email": Alias(String(&email), "e-mail"),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
+
+func Test_Parse_Alias_Map(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"meta.color": []string{"red"},
+	}
+
+	var meta map[string]string
+	err := ParseValues(data, Schema{
+		"meta": Alias(Map(&meta), "metadata"),
+	}, Strict())
+	must.NoError(t, err)
+	must.Eq(t, map[string]string{"color": "red"}, meta)
+}
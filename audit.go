@@ -0,0 +1,79 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"slices"
+)
+
+// Redacted replaces each value of a sensitive field in an AuditRecord.
+const Redacted = "[redacted]"
+
+// An AuditRecord is a structured record of a parse, as produced by the Audit
+// option, which an application may persist e.g. for compliance. The values of
+// sensitive fields are redacted.
+type AuditRecord struct {
+	// Fields describes each field of the schema which was parsed, in order
+	// of name. The fields of a Group are described individually, by their
+	// complete bracketed names. When the form is rejected before its fields
+	// are parsed, e.g. by StrictText, no fields are included.
+	Fields []AuditField
+
+	// Valid reports whether the parse succeeded.
+	Valid bool
+}
+
+// An AuditField is the record of parsing a single field of a form.
+type AuditField struct {
+	// Name is the name of the field in the schema.
+	Name string
+
+	// Provided reports whether any value was submitted for the field.
+	Provided bool
+
+	// Values are the submitted values, each replaced with Redacted if the
+	// field is sensitive.
+	Values []string
+
+	// Code is the catalog code of the error of the field, if it failed.
+	Code string
+
+	// Error is the message of the error of the field, and is always empty
+	// for sensitive fields since the message may contain the value.
+	Error string
+}
+
+// audit records the outcome err of parsing the field from data.
+func (f *compiledField) audit(data url.Values, err error) AuditField {
+	var values []string
+	if f.form == nil {
+		values = slices.Clone(data[f.name])
+	} else {
+		for _, key := range slices.Sorted(func(yield func(string) bool) {
+			for key := range data {
+				if f.form.claims(f.name, key) && !yield(key) {
+					return
+				}
+			}
+		}) {
+			values = append(values, data[key]...)
+		}
+	}
+
+	af := AuditField{
+		Name:     f.name,
+		Provided: len(values) > 0,
+		Values:   values,
+		Code:     ErrorCode(err),
+	}
+	if f.sensitive {
+		for i := range af.Values {
+			af.Values[i] = Redacted
+		}
+	} else if err != nil {
+		af.Error = err.Error()
+	}
+	return af
+}
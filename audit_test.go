@@ -0,0 +1,94 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/go-conceal"
+	"github.com/shoenig/test/must"
+)
+
+func Test_Audit(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"name":     {"bob"},
+		"password": {"hunter2"},
+		"age":      {"old"},
+	}
+
+	var record AuditRecord
+	var name, password, email string
+	var age int
+	err := ParseValues(data, Schema{
+		"name":     String(&name),
+		"password": Sensitive(String(&password)),
+		"age":      Int(&age),
+		"email":    StringOr(&email, "none"),
+	}, CollectAllErrors(), Audit(&record))
+	must.Error(t, err)
+	must.False(t, record.Valid)
+	must.SliceLen(t, 4, record.Fields)
+
+	must.Eq(t, "age", record.Fields[0].Name)
+	must.True(t, record.Fields[0].Provided)
+	must.Eq(t, []string{"old"}, record.Fields[0].Values)
+	must.Eq(t, "parse_failure", record.Fields[0].Code)
+	must.NotEq(t, "", record.Fields[0].Error)
+
+	must.Eq(t, AuditField{Name: "email"}, record.Fields[1])
+	must.Eq(t, AuditField{Name: "name", Provided: true, Values: []string{"bob"}}, record.Fields[2])
+	must.Eq(t, AuditField{Name: "password", Provided: true, Values: []string{Redacted}}, record.Fields[3])
+	must.Eq(t, []string{"hunter2"}, data["password"])
+}
+
+func Test_Audit_valid(t *testing.T) {
+	t.Parallel()
+
+	var record AuditRecord
+	var token string
+	schema := Schema{"token": Sensitive(String(&token))}
+
+	err := ParseValues(url.Values{}, schema, Audit(&record))
+	must.Error(t, err)
+	must.False(t, record.Valid)
+	must.Eq(t, "no_value", record.Fields[0].Code)
+	must.Eq(t, "", record.Fields[0].Error)
+
+	err = ParseValues(url.Values{"token": {"abc"}}, schema, Audit(&record))
+	must.NoError(t, err)
+	must.True(t, record.Valid)
+	must.SliceLen(t, 1, record.Fields)
+
+}
+
+func Test_Audit_Group(t *testing.T) {
+	t.Parallel()
+
+	var record AuditRecord
+	var name, city string
+	var password *conceal.Text
+	schema := Schema{
+		"user": Group(Schema{
+			"name":     String(&name),
+			"password": Secret(&password),
+			"address":  Group(Schema{"city": String(&city)}),
+		}),
+	}
+	err := ParseValues(url.Values{
+		"user[name]":          {"bob"},
+		"user[password]":      {"hunter2"},
+		"user[address][city]": {"Austin"},
+	}, schema, Audit(&record))
+	must.NoError(t, err)
+	must.True(t, record.Valid)
+	must.True(t, schema.Fields()[0].Sensitive)
+	must.Eq(t, []AuditField{
+		{Name: "user[address][city]", Provided: true, Values: []string{"Austin"}},
+		{Name: "user[name]", Provided: true, Values: []string{"bob"}},
+		{Name: "user[password]", Provided: true, Values: []string{Redacted}},
+	}, record.Fields)
+}
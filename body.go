@@ -0,0 +1,152 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// defaultMaxBody matches the limit net/http applies to urlencoded bodies read
+// by http.Request.ParseForm.
+const defaultMaxBody = 10 << 20
+
+var (
+	bufferPool = sync.Pool{
+		New: func() any { return new(bytes.Buffer) },
+	}
+
+	valuesPool = sync.Pool{
+		New: func() any { return make(url.Values) },
+	}
+
+	namesPool = sync.Pool{
+		New: func() any { return make(map[string]struct{}) },
+	}
+)
+
+// ParseBody is like Parse, but reads an application/x-www-form-urlencoded
+// request body directly rather than through http.Request.ParseForm. Only the
+// values of fields read by the schema are retained, values which need no
+// unescaping share the memory of the body, and scratch buffers are reused
+// between requests, reducing allocations on busy endpoints. Query parameters
+// of the request URL are parsed after the body, as with ParseForm.
+//
+// Parsers used with ParseBody must not retain the []string given to them
+// after parsing. Requests with any other content type are parsed by Parse. The
+// Form and PostForm fields of r are not populated.
+func (c *CompiledSchema) ParseBody(r *http.Request, opts ...Option) error {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if r.Method == http.MethodGet || mediaType != "application/x-www-form-urlencoded" || r.Body == nil {
+		return c.Parse(r, opts...)
+	}
+
+	return c.withOptions(opts, func(o *options) error {
+		data := valuesPool.Get().(url.Values)
+		defer func() {
+			clear(data)
+			valuesPool.Put(data)
+		}()
+
+		if err := c.readBody(r, data, o); err != nil {
+			return err
+		}
+		return c.parse(r.Context(), data, o)
+	})
+}
+
+// readBody scans the body and query of r into data, keeping only the values
+// of fields the schema reads, or every field if an option of the parse needs
+// to see them.
+func (c *CompiledSchema) readBody(r *http.Request, data url.Values, o *options) error {
+	limit := int64(defaultMaxBody)
+	if o.maxBytes > 0 {
+		limit = o.maxBytes
+	}
+
+	buf := bufferPool.Get().(*bytes.Buffer)
+	defer func() {
+		buf.Reset()
+		bufferPool.Put(buf)
+	}()
+
+	n, err := buf.ReadFrom(io.LimitReader(r.Body, limit+1))
+	switch {
+	case err != nil:
+		return err
+	case n > limit:
+		return fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, limit)
+	}
+
+	all := o.strict || o.text || o.maxValueLen > 0 || o.rest != nil
+
+	// the names of dropped fields still count towards MaxFields
+	var unclaimed map[string]struct{}
+	if !all && o.maxFields > 0 {
+		unclaimed = namesPool.Get().(map[string]struct{})
+		defer func() {
+			clear(unclaimed)
+			namesPool.Put(unclaimed)
+		}()
+	}
+
+	for _, s := range []string{buf.String(), r.URL.RawQuery} {
+		if err := c.scan(s, data, o, all, unclaimed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scan decodes the pairs of the urlencoded s into data. Unless all is set, the
+// pairs of fields not read by the schema are dropped, and their names are
+// added to unclaimed if it is not nil.
+func (c *CompiledSchema) scan(s string, data url.Values, o *options, all bool, unclaimed map[string]struct{}) error {
+	for s != "" {
+		var pair string
+		pair, s, _ = strings.Cut(s, "&")
+		if pair == "" {
+			continue
+		}
+
+		key, value, _ := strings.Cut(pair, "=")
+		key, err := unescape(key)
+		if err != nil {
+			return err
+		}
+
+		// fields submitted under a wire name are kept for renaming
+		if !all && !c.claimed(wireName(key, o.wire)) {
+			if unclaimed != nil {
+				unclaimed[key] = struct{}{}
+			}
+		} else {
+			value, err = unescape(value)
+			if err != nil {
+				return err
+			}
+			data[key] = append(data[key], value)
+		}
+
+		if o.maxFields > 0 && len(data)+len(unclaimed) > o.maxFields {
+			return fmt.Errorf("%s: %w", ErrParseFailure.Error(), ErrTooManyFields)
+		}
+	}
+	return nil
+}
+
+// unescape decodes s only if it contains escaped characters, so that values
+// which need no decoding are not copied.
+func unescape(s string) (string, error) {
+	if !strings.ContainsAny(s, "%+") {
+		return s, nil
+	}
+	return url.QueryUnescape(s)
+}
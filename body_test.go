@@ -0,0 +1,219 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_CompiledSchema_ParseBody(t *testing.T) {
+	t.Parallel()
+
+	var (
+		name  string
+		age   int
+		tags  []string
+		meta  map[string]string
+		notes string
+	)
+
+	compiled, err := Compile(Schema{
+		"name":  String(&name),
+		"age":   Int(&age),
+		"tags":  Strings(&tags),
+		"meta":  Map(&meta),
+		"notes": StringOr(&notes, "none"),
+	})
+	must.NoError(t, err)
+
+	request := httptest.NewRequestWithContext(
+		t.Context(), http.MethodPost, "/?notes=from+query", strings.NewReader(
+			"name=Bob+Smith&age=42&tags=a&tags=b%26c&meta.color=red&ignored=1&&",
+		),
+	)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	err = compiled.ParseBody(request)
+	must.NoError(t, err)
+	must.Eq(t, "Bob Smith", name)
+	must.Eq(t, 42, age)
+	must.Eq(t, []string{"a", "b&c"}, tags)
+	must.Eq(t, map[string]string{"color": "red"}, meta)
+	must.Eq(t, "from query", notes)
+	must.Nil(t, request.Form)
+}
+
+func Test_CompiledSchema_ParseBody_MaxFields(t *testing.T) {
+	t.Parallel()
+
+	var name string
+	compiled, err := Compile(Schema{"name": String(&name)})
+	must.NoError(t, err)
+
+	// fields are counted by name, not by value, on every path
+	data := url.Values{"x": {"1", "2", "3"}, "name": {"bob"}}
+	err = compiled.ParseBody(postForm(t, data), MaxFields(2))
+	must.NoError(t, err)
+	err = compiled.ParseValues(data, MaxFields(2))
+	must.NoError(t, err)
+
+	data["y"] = []string{"1"}
+	err = compiled.ParseBody(postForm(t, data), MaxFields(2))
+	must.ErrorIs(t, err, ErrTooManyFields)
+	err = compiled.ParseValues(data, MaxFields(2))
+	must.ErrorIs(t, err, ErrTooManyFields)
+}
+
+func Test_CompiledSchema_ParseBody_options(t *testing.T) {
+	t.Parallel()
+
+	var name string
+	compiled, err := Compile(Schema{"name": String(&name)})
+	must.NoError(t, err)
+
+	data := url.Values{"name": {"bob"}, "extra": {"1"}}
+
+	err = compiled.ParseBody(postForm(t, data))
+	must.NoError(t, err)
+	must.Eq(t, "bob", name)
+
+	err = compiled.ParseBody(postForm(t, data), Strict())
+	must.ErrorIs(t, err, ErrUnexpectedField)
+
+	err = compiled.ParseBody(postForm(t, data), MaxFields(1))
+	must.ErrorIs(t, err, ErrTooManyFields)
+
+	err = compiled.ParseBody(postForm(t, data), MaxBytes(8))
+	must.ErrorIs(t, err, ErrBodyTooLarge)
+
+	// options checking every field also see the fields the schema ignores
+	err = compiled.ParseBody(postForm(t, url.Values{"name": {"bob"}, "extra": {"a\x00b"}}), StrictText())
+	must.ErrorIs(t, err, ErrInvalidText)
+
+	err = compiled.ParseBody(postForm(t, url.Values{"name": {"bob"}, "extra": {"too long"}}), MaxValueLen(5))
+	must.ErrorIs(t, err, ErrValueTooLong)
+}
+
+func Test_CompiledSchema_ParseBody_malformed(t *testing.T) {
+	t.Parallel()
+
+	var name string
+	compiled, err := Compile(Schema{"name": String(&name)})
+	must.NoError(t, err)
+
+	request := httptest.NewRequestWithContext(
+		t.Context(), http.MethodPost, "/", strings.NewReader("name=%zz"),
+	)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	err = compiled.ParseBody(request)
+	must.Error(t, err)
+}
+
+func Test_CompiledSchema_ParseBody_fallback(t *testing.T) {
+	t.Parallel()
+
+	var name string
+	compiled, err := Compile(Schema{"name": String(&name)})
+	must.NoError(t, err)
+
+	request := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/?name=bob", nil)
+	err = compiled.ParseBody(request)
+	must.NoError(t, err)
+	must.Eq(t, "bob", name)
+}
+
+func BenchmarkCompiledSchema_ParseBody(b *testing.B) {
+	var (
+		name, country string
+		age           int
+		worth         float64
+		admin         bool
+	)
+
+	compiled, err := Compile(Schema{
+		"name":    String(&name),
+		"age":     Int(&age),
+		"worth":   Float(&worth),
+		"admin":   Bool(&admin),
+		"country": CountryCode(&country),
+	})
+	must.NoError(b, err)
+
+	body := benchmarkData.Encode()
+	request := httptest.NewRequest(http.MethodPost, "/", nil)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	b.ReportAllocs()
+	for b.Loop() {
+		request.Body = readCloser{strings.NewReader(body)}
+		_ = compiled.ParseBody(request)
+	}
+}
+
+func BenchmarkDefinition_ParseBody(b *testing.B) {
+	type person struct {
+		name, country string
+		age           int
+		worth         float64
+		admin         bool
+	}
+	definition := Define(func(p *person) Schema {
+		return Schema{
+			"name":    String(&p.name),
+			"age":     Int(&p.age),
+			"worth":   Float(&p.worth),
+			"admin":   Bool(&p.admin),
+			"country": CountryCode(&p.country),
+		}
+	})
+
+	body := benchmarkData.Encode()
+	request := httptest.NewRequest(http.MethodPost, "/", nil)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	b.ReportAllocs()
+	for b.Loop() {
+		request.Body = readCloser{strings.NewReader(body)}
+		_, _ = definition.ParseBody(request)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	var (
+		name, country string
+		age           int
+		worth         float64
+		admin         bool
+	)
+
+	schema := Schema{
+		"name":    String(&name),
+		"age":     Int(&age),
+		"worth":   Float(&worth),
+		"admin":   Bool(&admin),
+		"country": CountryCode(&country),
+	}
+
+	body := benchmarkData.Encode()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		_ = Parse(request, schema)
+	}
+}
+
+type readCloser struct {
+	*strings.Reader
+}
+
+func (readCloser) Close() error { return nil }
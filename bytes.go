@@ -0,0 +1,129 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// units maps each recognized (lower case) byte size suffix to its multiplier.
+var units = map[string]int64{
+	"":    1,
+	"b":   1,
+	"k":   1e3,
+	"kb":  1e3,
+	"m":   1e6,
+	"mb":  1e6,
+	"g":   1e9,
+	"gb":  1e9,
+	"t":   1e12,
+	"tb":  1e12,
+	"p":   1e15,
+	"pb":  1e15,
+	"e":   1e18,
+	"eb":  1e18,
+	"ki":  1 << 10,
+	"kib": 1 << 10,
+	"mi":  1 << 20,
+	"mib": 1 << 20,
+	"gi":  1 << 30,
+	"gib": 1 << 30,
+	"ti":  1 << 40,
+	"tib": 1 << 40,
+	"pi":  1 << 50,
+	"pib": 1 << 50,
+	"ei":  1 << 60,
+	"eib": 1 << 60,
+}
+
+type bytesParser struct {
+	required    bool
+	alt         int64
+	destination *int64
+}
+
+func (p *bytesParser) describe() field {
+	return field{
+		kind:        "bytes",
+		required:    p.required,
+		alt:         p.alt,
+		constraints: []string{"integer with optional unit (e.g. 512K, 10MiB, 2GB)"},
+	}
+}
+
+func (p *bytesParser) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *bytesParser) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	n, err := parseBytes(values[0])
+	if err != nil {
+		return err
+	}
+
+	*p.destination = n
+	return nil
+}
+
+// parseBytes parses a human-readable byte size. Decimal units (K, KB, M, MB,
+// etc.) are powers of 1000 and binary units (Ki, KiB, Mi, MiB, etc.) are
+// powers of 1024.
+func parseBytes(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+
+	multiplier, exists := units[strings.ToLower(strings.TrimSpace(s[i:]))]
+	if !exists {
+		return 0, ErrUnknownUnit
+	}
+
+	n, err := strconv.ParseInt(s[:i], 10, 64)
+	switch {
+	case err != nil:
+		return 0, err
+	case n > math.MaxInt64/multiplier:
+		return 0, ErrOutOfRange
+	}
+
+	return n * multiplier, nil
+}
+
+// Bytes is used to extract a form data value describing a size in bytes into
+// a Go int64. The value may be a plain integer, or an integer followed by a
+// decimal unit (K, KB, M, MB, G, GB, ...) or binary unit (Ki, KiB, Mi, MiB,
+// ...). If the value is malformed, overflows, or is missing then an error is
+// returned during parsing.
+func Bytes(n *int64) Parser {
+	return &bytesParser{
+		required:    true,
+		destination: n,
+	}
+}
+
+// BytesOr is used to extract a form data value describing a size in bytes into
+// a Go int64. If the value is missing, then the alt value is used instead.
+func BytesOr(n *int64, alt int64) Parser {
+	*n = alt
+	return &bytesParser{
+		required:    false,
+		alt:         alt,
+		destination: n,
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_parseBytes(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		input string
+		exp   int64
+	}{
+		{input: "0", exp: 0},
+		{input: "100", exp: 100},
+		{input: "100B", exp: 100},
+		{input: "512K", exp: 512_000},
+		{input: "512KiB", exp: 512 << 10},
+		{input: "10MiB", exp: 10 << 20},
+		{input: "10 mib", exp: 10 << 20},
+		{input: "2GB", exp: 2_000_000_000},
+		{input: "3Ti", exp: 3 << 40},
+		{input: "7EiB", exp: 7 << 60},
+	}
+
+	for _, tc := range cases {
+		n, err := parseBytes(tc.input)
+		must.NoError(t, err, must.Sprint(tc.input))
+		must.Eq(t, tc.exp, n, must.Sprint(tc.input))
+	}
+}
+
+func Test_parseBytes_invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := parseBytes("10XB")
+	must.ErrorIs(t, err, ErrUnknownUnit)
+
+	_, err = parseBytes("8EiB")
+	must.ErrorIs(t, err, ErrOutOfRange)
+
+	_, err = parseBytes("99999999999999999999")
+	must.Error(t, err)
+
+	_, err = parseBytes("-5MB")
+	must.Error(t, err)
+
+	_, err = parseBytes("MB")
+	must.Error(t, err)
+}
+
+func Test_Parse_Bytes(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"quota": []string{"5GiB"},
+	}
+
+	var quota, limit int64
+	err := ParseValues(data, Schema{
+		"quota": Bytes(&quota),
+		"limit": BytesOr(&limit, 1<<20),
+	})
+	must.NoError(t, err)
+	must.Eq(t, 5<<30, quota)
+	must.Eq(t, 1<<20, limit)
+}
+
+func Test_Parse_Bytes_missing(t *testing.T) {
+	t.Parallel()
+
+	var quota int64
+	err := ParseValues(url.Values{}, Schema{
+		"quota": Bytes(&quota),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// calendarType is a calendar unit with English names, given by String.
+type calendarType interface {
+	time.Weekday | time.Month
+	String() string
+}
+
+type calendarParser[T calendarType] struct {
+	kind        string
+	first       T
+	last        T
+	required    bool
+	alt         T
+	destination *T
+}
+
+func (p *calendarParser[T]) describe() field {
+	return field{
+		kind:        p.kind,
+		required:    p.required,
+		alt:         p.alt,
+		constraints: []string{fmt.Sprintf("%d to %d, or English name", p.first, p.last)},
+	}
+}
+
+func (p *calendarParser[T]) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *calendarParser[T]) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	value := strings.TrimSpace(values[0])
+
+	if n, err := strconv.Atoi(value); err == nil {
+		if n < int(p.first) || n > int(p.last) {
+			return ErrOutOfRange
+		}
+		*p.destination = T(n)
+		return nil
+	}
+
+	// accept the full name or its three letter abbreviation, in any case
+	for v := p.first; v <= p.last; v++ {
+		name := v.String()
+		if strings.EqualFold(value, name) || strings.EqualFold(value, name[:3]) {
+			*p.destination = v
+			return nil
+		}
+	}
+	return ErrUnknownName
+}
+
+// Weekday is used to extract a form data value into a Go time.Weekday. The
+// value may be a number from 0 (Sunday) to 6 (Saturday), or an English name
+// such as "Tuesday" or "tue". If the value is not a weekday or is missing then
+// an error is returned during parsing.
+func Weekday(d *time.Weekday) Parser {
+	return &calendarParser[time.Weekday]{
+		kind:        "weekday",
+		first:       time.Sunday,
+		last:        time.Saturday,
+		required:    true,
+		destination: d,
+	}
+}
+
+// WeekdayOr is used to extract a form data value into a Go time.Weekday. If
+// the value is missing, then the alt value is used instead.
+func WeekdayOr(d *time.Weekday, alt time.Weekday) Parser {
+	*d = alt
+	return &calendarParser[time.Weekday]{
+		kind:        "weekday",
+		first:       time.Sunday,
+		last:        time.Saturday,
+		required:    false,
+		alt:         alt,
+		destination: d,
+	}
+}
+
+// Month is used to extract a form data value into a Go time.Month. The value
+// may be a number from 1 (January) to 12 (December), or an English name such
+// as "March" or "mar". If the value is not a month or is missing then an error
+// is returned during parsing.
+func Month(m *time.Month) Parser {
+	return &calendarParser[time.Month]{
+		kind:        "month",
+		first:       time.January,
+		last:        time.December,
+		required:    true,
+		destination: m,
+	}
+}
+
+// MonthOr is used to extract a form data value into a Go time.Month. If the
+// value is missing, then the alt value is used instead.
+func MonthOr(m *time.Month, alt time.Month) Parser {
+	*m = alt
+	return &calendarParser[time.Month]{
+		kind:        "month",
+		first:       time.January,
+		last:        time.December,
+		required:    false,
+		alt:         alt,
+		destination: m,
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_Weekday(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]time.Weekday{
+		"0":        time.Sunday,
+		"2":        time.Tuesday,
+		"6":        time.Saturday,
+		"Tuesday":  time.Tuesday,
+		"tuesday":  time.Tuesday,
+		"TUE":      time.Tuesday,
+		" friday ": time.Friday,
+	}
+	for value, exp := range cases {
+		var d time.Weekday
+		err := ParseValues(url.Values{"day": {value}}, Schema{
+			"day": Weekday(&d),
+		})
+		must.NoError(t, err, must.Sprint(value))
+		must.Eq(t, exp, d, must.Sprint(value))
+	}
+}
+
+func Test_Parse_Weekday_invalid(t *testing.T) {
+	t.Parallel()
+
+	var d time.Weekday
+	err := ParseValues(url.Values{"day": {"7"}}, Schema{"day": Weekday(&d)})
+	must.ErrorIs(t, err, ErrOutOfRange)
+
+	err = ParseValues(url.Values{"day": {"tues"}}, Schema{"day": Weekday(&d)})
+	must.ErrorIs(t, err, ErrUnknownName)
+
+	err = ParseValues(url.Values{}, Schema{"day": Weekday(&d)})
+	must.ErrorIs(t, err, ErrNoValue)
+
+	err = ParseValues(url.Values{}, Schema{"day": WeekdayOr(&d, time.Monday)})
+	must.NoError(t, err)
+	must.Eq(t, time.Monday, d)
+}
+
+func Test_Parse_Month(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]time.Month{
+		"1":         time.January,
+		"12":        time.December,
+		"March":     time.March,
+		"sep":       time.September,
+		"SEPTEMBER": time.September,
+	}
+	for value, exp := range cases {
+		var m time.Month
+		err := ParseValues(url.Values{"month": {value}}, Schema{
+			"month": Month(&m),
+		})
+		must.NoError(t, err, must.Sprint(value))
+		must.Eq(t, exp, m, must.Sprint(value))
+	}
+}
+
+func Test_Parse_Month_invalid(t *testing.T) {
+	t.Parallel()
+
+	var m time.Month
+	err := ParseValues(url.Values{"month": {"0"}}, Schema{"month": Month(&m)})
+	must.ErrorIs(t, err, ErrOutOfRange)
+
+	err = ParseValues(url.Values{"month": {"13"}}, Schema{"month": Month(&m)})
+	must.ErrorIs(t, err, ErrOutOfRange)
+
+	err = ParseValues(url.Values{"month": {"smarch"}}, Schema{"month": Month(&m)})
+	must.ErrorIs(t, err, ErrUnknownName)
+
+	err = ParseValues(url.Values{}, Schema{"month": MonthOr(&m, time.June)})
+	must.NoError(t, err)
+	must.Eq(t, time.June, m)
+}
@@ -0,0 +1,146 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"strings"
+
+	"github.com/shoenig/go-conceal"
+)
+
+// A CardBrand identifies the issuing network of a payment card number.
+type CardBrand string
+
+const (
+	CardUnknown    CardBrand = "unknown"
+	CardVisa       CardBrand = "visa"
+	CardMastercard CardBrand = "mastercard"
+	CardAmex       CardBrand = "amex"
+	CardDiscover   CardBrand = "discover"
+	CardDiners     CardBrand = "diners"
+	CardJCB        CardBrand = "jcb"
+	CardUnionPay   CardBrand = "unionpay"
+)
+
+type cardParser struct {
+	destination **conceal.Text
+	brand       *CardBrand
+}
+
+func (p *cardParser) describe() field {
+	return field{
+		kind:        "card number",
+		required:    true,
+		sensitive:   true,
+		constraints: []string{"12 to 19 digits passing the Luhn check"},
+	}
+}
+
+func (p *cardParser) destinations() []any {
+	if p.brand == nil {
+		return []any{p.destination}
+	}
+	return []any{p.destination, p.brand}
+}
+
+func (p *cardParser) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0:
+		return ErrNoValue
+	}
+
+	// never include the number itself in any error
+	pan := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, values[0])
+
+	if !luhn(pan) {
+		return ErrCardNumber
+	}
+
+	*p.destination = conceal.New(pan)
+	if p.brand != nil {
+		*p.brand = cardBrand(pan)
+	}
+	return nil
+}
+
+// luhn reports whether pan is a plausible card number consisting only of
+// digits and satisfying the Luhn checksum.
+func luhn(pan string) bool {
+	if len(pan) < 12 || len(pan) > 19 {
+		return false
+	}
+
+	sum := 0
+	for i := range len(pan) {
+		c := pan[len(pan)-1-i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if i%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// cardBrand identifies the brand of pan by its issuer identification number.
+func cardBrand(pan string) CardBrand {
+	prefix := func(n int) int {
+		v := 0
+		for i := range min(n, len(pan)) {
+			v = v*10 + int(pan[i]-'0')
+		}
+		return v
+	}
+
+	switch p2, p3, p4 := prefix(2), prefix(3), prefix(4); {
+	case pan[0] == '4':
+		return CardVisa
+	case p2 >= 51 && p2 <= 55, p4 >= 2221 && p4 <= 2720:
+		return CardMastercard
+	case p2 == 34, p2 == 37:
+		return CardAmex
+	case p4 == 6011, p2 == 65, p3 >= 644 && p3 <= 649:
+		return CardDiscover
+	case p2 == 36, p2 == 38, p2 == 39, p3 >= 300 && p3 <= 305:
+		return CardDiners
+	case p4 >= 3528 && p4 <= 3589:
+		return CardJCB
+	case p2 == 62:
+		return CardUnionPay
+	default:
+		return CardUnknown
+	}
+}
+
+// CardNumber is used to extract a form data value containing a payment card
+// number into a Go conceal.Text, so that the number is never exposed through
+// logging or printing. Spaces and hyphens are removed from the value before
+// it is checked. If the value is not a valid card number or is missing, then
+// an error is returned during parsing; the error never contains the number.
+func CardNumber(pan **conceal.Text) Parser {
+	return &cardParser{
+		destination: pan,
+	}
+}
+
+// CardNumberBrand is like CardNumber, additionally detecting the brand of the
+// card and writing it into brand.
+func CardNumberBrand(pan **conceal.Text, brand *CardBrand) Parser {
+	return &cardParser{
+		destination: pan,
+		brand:       brand,
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/go-conceal"
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_CardNumber(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pan   *conceal.Text
+		brand CardBrand
+	)
+
+	err := ParseValues(url.Values{"card": {"4111 1111 1111 1111"}}, Schema{
+		"card": CardNumberBrand(&pan, &brand),
+	})
+	must.NoError(t, err)
+	must.Eq(t, "4111111111111111", pan.Unveil())
+	must.Eq(t, CardVisa, brand)
+	must.StrNotContains(t, pan.String(), "4111")
+}
+
+func Test_Parse_CardNumber_invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{
+		"4111 1111 1111 1112",
+		"4111-1111-1111-111a",
+		"4111",
+		"",
+	} {
+		var pan *conceal.Text
+		err := ParseValues(url.Values{"card": {value}}, Schema{
+			"card": CardNumber(&pan),
+		})
+		must.ErrorIs(t, err, ErrCardNumber, must.Sprint(value))
+		must.StrNotContains(t, err.Error(), "4111")
+		must.Nil(t, pan)
+	}
+}
+
+func Test_cardBrand(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]CardBrand{
+		"4012888888881881": CardVisa,
+		"5555555555554444": CardMastercard,
+		"2223003122003222": CardMastercard,
+		"378282246310005":  CardAmex,
+		"6011111111111117": CardDiscover,
+		"30569309025904":   CardDiners,
+		"3530111333300000": CardJCB,
+		"6200000000000005": CardUnionPay,
+		"9999999999999995": CardUnknown,
+	}
+
+	for pan, exp := range cases {
+		must.True(t, luhn(pan), must.Sprint(pan))
+		must.Eq(t, exp, cardBrand(pan), must.Sprint(pan))
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// A Step transforms or validates each value of a field before it is parsed, as
+// part of a Chain.
+type Step struct {
+	description string
+	apply       func(string) (string, error)
+}
+
+// Transform creates a Step which replaces each value with the result of fn.
+func Transform(fn func(string) string) Step {
+	return Step{
+		apply: func(value string) (string, error) {
+			return fn(value), nil
+		},
+	}
+}
+
+// Require creates a Step which fails if fn returns an error for any value.
+// The description is reported as a constraint of the field, e.g. by Markdown.
+func Require(description string, fn func(string) error) Step {
+	return Step{
+		description: description,
+		apply: func(value string) (string, error) {
+			return value, fn(value)
+		},
+	}
+}
+
+// Trim is a Step removing leading and trailing white space.
+func Trim() Step {
+	return Transform(strings.TrimSpace)
+}
+
+// Lower is a Step converting values to lower case.
+func Lower() Step {
+	return Transform(strings.ToLower)
+}
+
+// Upper is a Step converting values to upper case.
+func Upper() Step {
+	return Transform(strings.ToUpper)
+}
+
+// MinLen is a Step requiring values to contain at least n characters.
+func MinLen(n int) Step {
+	return Require(fmt.Sprintf("at least %d characters", n), func(value string) error {
+		if utf8.RuneCountInString(value) < n {
+			return fmt.Errorf("%w: must be at least %d characters", ErrLength, n)
+		}
+		return nil
+	})
+}
+
+// MaxLen is a Step requiring values to contain at most n characters.
+func MaxLen(n int) Step {
+	return Require(fmt.Sprintf("at most %d characters", n), func(value string) error {
+		if utf8.RuneCountInString(value) > n {
+			return fmt.Errorf("%w: must be at most %d characters", ErrLength, n)
+		}
+		return nil
+	})
+}
+
+// Match is a Step requiring values to match re.
+func Match(re *regexp.Regexp) Step {
+	return Require("matches "+re.String(), func(value string) error {
+		if !re.MatchString(value) {
+			return fmt.Errorf("%w: %s", ErrPattern, re)
+		}
+		return nil
+	})
+}
+
+type chainParser struct {
+	Parser
+	steps []Step
+}
+
+func (p *chainParser) describe() field {
+	f := describe(p.Parser)
+	f.constraints = append(f.constraints[:len(f.constraints):len(f.constraints)], p.constraints()...)
+	return f
+}
+
+func (p *chainParser) constraints() []string {
+	var constraints []string
+	for _, step := range p.steps {
+		if step.description != "" {
+			constraints = append(constraints, step.description)
+		}
+	}
+	return constraints
+}
+
+func (p *chainParser) destinations() []any {
+	return destinations(p.Parser)
+}
+
+func (p *chainParser) parseForm(ctx context.Context, name string, data url.Values, o *options) error {
+	values := make([]string, len(data[name]))
+	for i, value := range data[name] {
+		for _, step := range p.steps {
+			var err error
+			if value, err = step.apply(value); err != nil {
+				return err
+			}
+		}
+		values[i] = value
+	}
+
+	view := url.Values{name: values}
+	if _, ok := p.Parser.(formParser); ok {
+		view = maps.Clone(data)
+		view[name] = values
+	}
+	return parseField(ctx, name, p.Parser, view, o)
+}
+
+func (p *chainParser) claims(name, key string) bool {
+	if fp, ok := p.Parser.(formParser); ok {
+		return fp.claims(name, key)
+	}
+	return key == name
+}
+
+// Chain wraps p so that each value of the field passes through steps, in
+// order, before being parsed by p. For example,
+//
+//	Chain(String(&username), Trim(), Lower(), MinLen(3), Match(usernames))
+//
+// trims and lower-cases the value, then checks its length and pattern before
+// storing it. If any step fails, then its error is returned during parsing.
+// Steps apply only to the values submitted under the name of the field, and
+// not to the other values read by parsers such as Map.
+func Chain(p Parser, steps ...Step) Parser {
+	return &chainParser{
+		Parser: p,
+		steps:  steps,
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+var usernames = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+func Test_Parse_Chain(t *testing.T) {
+	t.Parallel()
+
+	var username, code string
+	err := ParseValues(url.Values{"username": {"  Alice_1 "}, "code": {"ab"}}, Schema{
+		"username": Chain(String(&username), Trim(), Lower(), MinLen(3), MaxLen(16), Match(usernames)),
+		"code":     Chain(String(&code), Upper()),
+	})
+	must.NoError(t, err)
+	must.Eq(t, "alice_1", username)
+	must.Eq(t, "AB", code)
+}
+
+func Test_Parse_Chain_invalid(t *testing.T) {
+	t.Parallel()
+
+	var username string
+	p := Chain(String(&username), Trim(), Lower(), MinLen(3), Match(usernames))
+
+	err := ParseValues(url.Values{"username": {" Al "}}, Schema{"username": p})
+	must.ErrorIs(t, err, ErrLength)
+	must.StrHasSuffix(t, "must be at least 3 characters", err.Error())
+
+	err = ParseValues(url.Values{"username": {"1alice"}}, Schema{"username": p})
+	must.ErrorIs(t, err, ErrPattern)
+
+	err = ParseValues(url.Values{}, Schema{"username": p})
+	must.ErrorIs(t, err, ErrNoValue)
+
+	must.Eq(t, "", username)
+}
+
+func Test_Parse_Chain_custom(t *testing.T) {
+	t.Parallel()
+
+	errReserved := errors.New("name is reserved")
+	var name string
+	p := Chain(String(&name),
+		Transform(func(s string) string { return s + "!" }),
+		Require("not reserved", func(s string) error {
+			if s == "root!" {
+				return errReserved
+			}
+			return nil
+		}),
+	)
+
+	err := ParseValues(url.Values{"name": {"bob"}}, Schema{"name": p})
+	must.NoError(t, err)
+	must.Eq(t, "bob!", name)
+
+	err = ParseValues(url.Values{"name": {"root"}}, Schema{"name": p})
+	must.ErrorIs(t, err, errReserved)
+
+	must.Eq(t, []string{"not reserved"}, describe(p).constraints)
+}
+
+func Test_Parse_Chain_form(t *testing.T) {
+	t.Parallel()
+
+	var meta map[string]string
+	err := ParseValues(url.Values{"meta.k": {"v"}}, Schema{
+		"meta": Chain(Map(&meta), Upper()),
+	}, Strict())
+	must.NoError(t, err)
+	must.Eq(t, map[string]string{"k": "v"}, meta)
+}
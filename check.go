@@ -0,0 +1,78 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// A targeter is a Parser capable of reporting the pointers it writes through.
+type targeter interface {
+	destinations() []any
+}
+
+func destinations(p Parser) []any {
+	if t, ok := p.(targeter); ok {
+		return t.destinations()
+	}
+	return nil
+}
+
+// isNil reports whether the destination d is a nil pointer or interface.
+func isNil(d any) bool {
+	v := reflect.ValueOf(d)
+	return !v.IsValid() || v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// Check reports mistakes in s which would otherwise cause a panic or silently
+// wrong results while parsing: empty field names, missing parsers, parsers
+// with a nil destination, several parsers writing to the same destination,
+// and fields which are also read by another parser, e.g. as an alias. The
+// returned error wraps ErrInvalidSchema and describes the first mistake found.
+func (s Schema) Check() error {
+	names := slices.Sorted(maps.Keys(s))
+	writers := make(map[any]string)
+
+	for _, name := range names {
+		parser := s[name]
+		switch {
+		case name == "":
+			return fmt.Errorf("%w: empty field name", ErrInvalidSchema)
+		case parser == nil:
+			return fmt.Errorf("%w: field %q has no parser", ErrInvalidSchema, name)
+		}
+
+		for _, d := range destinations(parser) {
+			if isNil(d) {
+				return fmt.Errorf("%w: field %q has a nil destination", ErrInvalidSchema, name)
+			}
+			if reflect.ValueOf(d).Kind() != reflect.Pointer {
+				continue
+			}
+			if other, exists := writers[d]; exists && other == name {
+				return fmt.Errorf("%w: field %q writes to the same destination twice", ErrInvalidSchema, name)
+			} else if exists {
+				return fmt.Errorf("%w: fields %q and %q write to the same destination", ErrInvalidSchema, other, name)
+			}
+			writers[d] = name
+		}
+	}
+
+	for _, name := range names {
+		f := compileField(name, s[name])
+		if f.form == nil {
+			continue
+		}
+		for _, other := range names {
+			if other != name && f.form.claims(name, other) {
+				return fmt.Errorf("%w: field %q is also read by field %q", ErrInvalidSchema, other, name)
+			}
+		}
+	}
+
+	return nil
+}
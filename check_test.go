@@ -0,0 +1,91 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Schema_Check(t *testing.T) {
+	t.Parallel()
+
+	var (
+		name  string
+		email string
+		lo    int
+		hi    int
+		meta  map[string]string
+	)
+
+	err := Schema{
+		"name":  Annotate(String(&name), Label("Name")),
+		"email": Alias(Sensitive(String(&email)), "mail"),
+		"price": IntRangePair(&lo, &hi),
+		"meta":  Map(&meta),
+		"trap":  Honeypot(),
+	}.Check()
+	must.NoError(t, err)
+}
+
+func Test_Schema_Check_invalid(t *testing.T) {
+	t.Parallel()
+
+	var (
+		name  string
+		age   int
+		lo    int
+		email string
+		meta  map[string]string
+		nilS  *string
+	)
+
+	cases := map[string]struct {
+		schema Schema
+		exp    string
+	}{
+		"empty name": {
+			schema: Schema{"": String(&name)},
+			exp:    "invalid schema: empty field name",
+		},
+		"nil parser": {
+			schema: Schema{"name": nil},
+			exp:    `invalid schema: field "name" has no parser`,
+		},
+		"nil destination": {
+			schema: Schema{"name": String(nilS)},
+			exp:    `invalid schema: field "name" has a nil destination`,
+		},
+		"nil wrapped destination": {
+			schema: Schema{"name": WithMultiple(Deprecated(String(nilS), "old"), TakeFirst)},
+			exp:    `invalid schema: field "name" has a nil destination`,
+		},
+		"shared destination": {
+			schema: Schema{"first": String(&name), "last": StringOr(&name, "")},
+			exp:    `invalid schema: fields "first" and "last" write to the same destination`,
+		},
+		"shared within field": {
+			schema: Schema{"age": Int(&age), "range": IntRangePair(&lo, &lo)},
+			exp:    `invalid schema: field "range" writes to the same destination twice`,
+		},
+		"alias collision": {
+			schema: Schema{"email": Alias(String(&email), "mail"), "mail": String(&name)},
+			exp:    `invalid schema: field "mail" is also read by field "email"`,
+		},
+		"map collision": {
+			schema: Schema{"meta": Map(&meta), "meta.k": String(&name)},
+			exp:    `invalid schema: field "meta.k" is also read by field "meta"`,
+		},
+	}
+
+	for name, tc := range cases {
+		err := tc.schema.Check()
+		must.ErrorIs(t, err, ErrInvalidSchema, must.Sprint(name))
+		must.EqError(t, err, tc.exp, must.Sprint(name))
+
+		_, err = Compile(tc.schema)
+		must.ErrorIs(t, err, ErrInvalidSchema, must.Sprint(name))
+	}
+}
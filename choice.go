@@ -0,0 +1,210 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// quoted renders values as a comma separated list of quoted strings.
+func quoted[T StringType](values []T) string {
+	q := make([]string, len(values))
+	for i, v := range values {
+		q[i] = strconv.Quote(string(v))
+	}
+	return strings.Join(q, ", ")
+}
+
+type radioParser[T StringType] struct {
+	choices     map[string]T
+	required    bool
+	alt         T
+	destination *T
+}
+
+func (p *radioParser[T]) describe() field {
+	return field{
+		kind:        "radio",
+		required:    p.required,
+		alt:         p.alt,
+		constraints: []string{"one of " + quoted(slices.Sorted(maps.Keys(p.choices)))},
+	}
+}
+
+func (p *radioParser[T]) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *radioParser[T]) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	choice, exists := p.choices[values[0]]
+	if !exists {
+		return fmt.Errorf("%w: %q is not one of %s",
+			ErrInvalidChoice, values[0], quoted(slices.Sorted(maps.Keys(p.choices))))
+	}
+
+	*p.destination = choice
+	return nil
+}
+
+// Radio is used to extract the value of a radio group into a typed Go string
+// constant, where choices maps each value of the group to its constant. If the
+// value is not one of the choices then an error listing the choices is
+// returned during parsing, as it is if the value is missing.
+func Radio[T StringType](s *T, choices map[string]T) Parser {
+	return &radioParser[T]{
+		choices:     choices,
+		required:    true,
+		destination: s,
+	}
+}
+
+// RadioOr is used to extract the value of a radio group into a typed Go
+// string constant. If the value is missing, then the alt value is used
+// instead.
+func RadioOr[T StringType](s *T, choices map[string]T, alt T) Parser {
+	*s = alt
+	return &radioParser[T]{
+		choices:     choices,
+		required:    false,
+		alt:         alt,
+		destination: s,
+	}
+}
+
+type multiSelectParser[T StringType] struct {
+	allowed     []T
+	required    bool
+	alt         []T
+	destination *[]T
+}
+
+func (p *multiSelectParser[T]) describe() field {
+	return field{
+		kind:        "multi select",
+		required:    p.required,
+		multiple:    true,
+		alt:         p.alt,
+		constraints: []string{"each one of " + quoted(p.allowed)},
+	}
+}
+
+func (p *multiSelectParser[T]) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *multiSelectParser[T]) Parse(values []string) error {
+	switch {
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	selected := make([]T, 0, len(values))
+	var invalid []string
+	for _, value := range values {
+		if !slices.Contains(p.allowed, T(value)) {
+			invalid = append(invalid, value)
+			continue
+		}
+		selected = append(selected, T(value))
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidChoice, quoted(invalid))
+	}
+
+	*p.destination = selected
+	return nil
+}
+
+// MultiSelect is used to extract every value of a field, as submitted by a
+// select with the multiple attribute, into a slice of Go strings. Each value
+// must be one of allowed, otherwise an error listing every invalid value is
+// returned during parsing. If the value is missing then an error is returned
+// during parsing.
+func MultiSelect[T StringType](s *[]T, allowed ...T) Parser {
+	return &multiSelectParser[T]{
+		allowed:     allowed,
+		required:    true,
+		destination: s,
+	}
+}
+
+// MultiSelectOr is used to extract every value of a field into a slice of Go
+// strings, each of which must be one of allowed. If the value is missing, then
+// the alt value is used instead.
+func MultiSelectOr[T StringType](s *[]T, alt []T, allowed ...T) Parser {
+	*s = alt
+	return &multiSelectParser[T]{
+		allowed:     allowed,
+		required:    false,
+		alt:         alt,
+		destination: s,
+	}
+}
+
+type flagsParser[T IntType] struct {
+	mapping     map[string]T
+	destination *T
+}
+
+func (p *flagsParser[T]) describe() field {
+	return field{
+		kind:        "flags",
+		required:    false,
+		multiple:    true,
+		constraints: []string{"each one of " + quoted(slices.Sorted(maps.Keys(p.mapping)))},
+	}
+}
+
+func (p *flagsParser[T]) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *flagsParser[T]) Parse(values []string) error {
+	var (
+		flags   T
+		invalid []string
+	)
+	for _, value := range values {
+		flag, exists := p.mapping[value]
+		if !exists {
+			invalid = append(invalid, value)
+			continue
+		}
+		flags |= flag
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidChoice, quoted(invalid))
+	}
+
+	*p.destination = flags
+	return nil
+}
+
+// Flags is used to extract the values of a checkbox group into a Go integer
+// bitmask, where mapping gives the flag constant of each checkbox value. The
+// flags of every checked box are combined with bitwise OR. Since a browser
+// submits nothing when no box is checked, a missing value results in no
+// flags being set. If a value is not in mapping then an error is returned
+// during parsing.
+func Flags[T IntType](flags *T, mapping map[string]T) Parser {
+	return &flagsParser[T]{
+		mapping:     mapping,
+		destination: flags,
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+type plan string
+
+const (
+	planFree plan = "free"
+	planPro  plan = "pro"
+)
+
+var planChoices = map[string]plan{
+	"free": planFree,
+	"pro":  planPro,
+}
+
+func Test_Parse_Radio(t *testing.T) {
+	t.Parallel()
+
+	var p plan
+	err := ParseValues(url.Values{"plan": {"pro"}}, Schema{
+		"plan": Radio(&p, planChoices),
+	})
+	must.NoError(t, err)
+	must.Eq(t, planPro, p)
+
+	err = ParseValues(url.Values{}, Schema{
+		"plan": RadioOr(&p, planChoices, planFree),
+	})
+	must.NoError(t, err)
+	must.Eq(t, planFree, p)
+}
+
+func Test_Parse_Radio_invalid(t *testing.T) {
+	t.Parallel()
+
+	var p plan
+	err := ParseValues(url.Values{"plan": {"enterprise"}}, Schema{
+		"plan": Radio(&p, planChoices),
+	})
+	must.ErrorIs(t, err, ErrInvalidChoice)
+	must.StrContains(t, err.Error(), `"enterprise" is not one of "free", "pro"`)
+
+	err = ParseValues(url.Values{}, Schema{
+		"plan": Radio(&p, planChoices),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
+
+func Test_Parse_MultiSelect(t *testing.T) {
+	t.Parallel()
+
+	var toppings []string
+	err := ParseValues(url.Values{"toppings": {"cheese", "olives"}}, Schema{
+		"toppings": MultiSelect(&toppings, "cheese", "olives", "peppers"),
+	})
+	must.NoError(t, err)
+	must.Eq(t, []string{"cheese", "olives"}, toppings)
+
+	err = ParseValues(url.Values{}, Schema{
+		"toppings": MultiSelectOr(&toppings, []string{"cheese"}, "cheese", "olives"),
+	})
+	must.NoError(t, err)
+	must.Eq(t, []string{"cheese"}, toppings)
+}
+
+func Test_Parse_MultiSelect_invalid(t *testing.T) {
+	t.Parallel()
+
+	var plans []plan
+	err := ParseValues(url.Values{"plans": {"free", "gold", "pro", "silver"}}, Schema{
+		"plans": MultiSelect(&plans, planFree, planPro),
+	})
+	must.ErrorIs(t, err, ErrInvalidChoice)
+	must.StrHasSuffix(t, `: "gold", "silver"`, err.Error())
+	must.SliceEmpty(t, plans)
+
+	err = ParseValues(url.Values{}, Schema{
+		"plans": MultiSelect(&plans, planFree, planPro),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
+
+type permission uint8
+
+const (
+	permRead permission = 1 << iota
+	permWrite
+	permAdmin
+)
+
+var permissions = map[string]permission{
+	"read":  permRead,
+	"write": permWrite,
+	"admin": permAdmin,
+}
+
+func Test_Parse_Flags(t *testing.T) {
+	t.Parallel()
+
+	var perms permission
+	err := ParseValues(url.Values{"perms": {"read", "admin"}}, Schema{
+		"perms": Flags(&perms, permissions),
+	})
+	must.NoError(t, err)
+	must.Eq(t, permRead|permAdmin, perms)
+
+	// no boxes were checked
+	err = ParseValues(url.Values{}, Schema{
+		"perms": Flags(&perms, permissions),
+	})
+	must.NoError(t, err)
+	must.Zero(t, perms)
+
+	err = ParseValues(url.Values{"perms": {"read", "root"}}, Schema{
+		"perms": Flags(&perms, permissions),
+	})
+	must.ErrorIs(t, err, ErrInvalidChoice)
+	must.StrContains(t, err.Error(), `"root"`)
+}
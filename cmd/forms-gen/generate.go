@@ -0,0 +1,192 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const annotation = "forms:generate"
+
+// parsers maps the source form of each supported field type to the names of
+// its required and optional parser constructors, and the zero value given as
+// the default of the optional parser.
+var parsers = map[string]struct {
+	required, optional, zero string
+}{
+	"string":            {"String", "StringOr", `""`},
+	"[]string":          {"Strings", "StringsOr", "nil"},
+	"int":               {"Int", "IntOr", "0"},
+	"int8":              {"Int", "IntOr", "0"},
+	"int16":             {"Int", "IntOr", "0"},
+	"int32":             {"Int", "IntOr", "0"},
+	"int64":             {"Int", "IntOr", "0"},
+	"uint":              {"Int", "IntOr", "0"},
+	"uint8":             {"Int", "IntOr", "0"},
+	"uint16":            {"Int", "IntOr", "0"},
+	"uint32":            {"Int", "IntOr", "0"},
+	"uint64":            {"Int", "IntOr", "0"},
+	"float64":           {"Float", "FloatOr", "0"},
+	"bool":              {"Bool", "BoolOr", "false"},
+	"map[string]string": {"Map", "MapOr", "nil"},
+	"*conceal.Text":     {"Secret", "", ""},
+	"net.HardwareAddr":  {"MAC", "", ""},
+}
+
+type binding struct {
+	name, field, constructor, alt string
+}
+
+type schema struct {
+	typeName string
+	bindings []binding
+}
+
+// generate returns the formatted Go source of the schema functions for every
+// annotated struct in source.
+func generate(filename string, source []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, source, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		schemas []schema
+		errs    []error
+	)
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok || !annotated(gen.Doc, ts.Doc) {
+				continue
+			}
+			s, err := structSchema(fset, ts.Name.Name, st)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			schemas = append(schemas, s)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	if len(schemas) == 0 {
+		return nil, fmt.Errorf("%s: no structs annotated with //%s", filename, annotation)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by forms-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", file.Name.Name)
+	fmt.Fprintf(&buf, "import \"cattlecloud.net/go/forms\"\n")
+
+	for _, s := range schemas {
+		fmt.Fprintf(&buf, "\n// FormSchema returns the forms.Schema binding form values to the fields of x.\n")
+		fmt.Fprintf(&buf, "func (x *%s) FormSchema() forms.Schema {\n", s.typeName)
+		fmt.Fprintf(&buf, "\treturn forms.Schema{\n")
+		for _, b := range s.bindings {
+			if b.alt == "" {
+				fmt.Fprintf(&buf, "\t\t%q: forms.%s(&x.%s),\n", b.name, b.constructor, b.field)
+			} else {
+				fmt.Fprintf(&buf, "\t\t%q: forms.%s(&x.%s, %s),\n", b.name, b.constructor, b.field, b.alt)
+			}
+		}
+		fmt.Fprintf(&buf, "\t}\n}\n")
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+func annotated(groups ...*ast.CommentGroup) bool {
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		for _, c := range group.List {
+			if strings.TrimSpace(strings.TrimPrefix(c.Text, "//")) == annotation {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func structSchema(fset *token.FileSet, typeName string, st *ast.StructType) (schema, error) {
+	s := schema{typeName: typeName}
+	for _, f := range st.Fields.List {
+		if f.Tag == nil {
+			continue
+		}
+
+		tag, err := strconv.Unquote(f.Tag.Value)
+		if err != nil {
+			return s, err
+		}
+
+		value, ok := reflect.StructTag(tag).Lookup("form")
+		if !ok || value == "-" {
+			continue
+		}
+
+		name, option, _ := strings.Cut(value, ",")
+		if option != "" && option != "optional" {
+			return s, fmt.Errorf("%s: unknown form tag option %q", fset.Position(f.Pos()), option)
+		}
+
+		typ := typeString(fset, f.Type)
+		p, ok := parsers[typ]
+		if !ok {
+			return s, fmt.Errorf("%s: unsupported field type %s", fset.Position(f.Pos()), typ)
+		}
+
+		constructor, alt := p.required, ""
+		if option == "optional" {
+			if p.optional == "" {
+				return s, fmt.Errorf("%s: field type %s cannot be optional", fset.Position(f.Pos()), typ)
+			}
+			constructor, alt = p.optional, p.zero
+		}
+
+		for _, ident := range f.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			fieldName := name
+			if fieldName == "" {
+				fieldName = ident.Name
+			}
+			s.bindings = append(s.bindings, binding{
+				name:        fieldName,
+				field:       ident.Name,
+				constructor: constructor,
+				alt:         alt,
+			})
+		}
+	}
+	return s, nil
+}
+
+// typeString renders the source form of a field type expression.
+func typeString(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = format.Node(&buf, fset, expr)
+	return buf.String()
+}
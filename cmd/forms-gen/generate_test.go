@@ -0,0 +1,76 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_generate(t *testing.T) {
+	t.Parallel()
+
+	source := []byte(`package app
+
+import "github.com/shoenig/go-conceal"
+
+//forms:generate
+type Signup struct {
+	Name     string        ` + "`form:\"name\"`" + `
+	Age      int           ` + "`form:\"age,optional\"`" + `
+	Password *conceal.Text ` + "`form:\"password\"`" + `
+	Tags     []string      ` + "`form:\"tags,optional\"`" + `
+	Ignored  string
+	Skipped  string        ` + "`form:\"-\"`" + `
+}
+
+type Other struct {
+	Name string ` + "`form:\"name\"`" + `
+}
+`)
+
+	exp := `// Code generated by forms-gen. DO NOT EDIT.
+
+package app
+
+import "cattlecloud.net/go/forms"
+
+// FormSchema returns the forms.Schema binding form values to the fields of x.
+func (x *Signup) FormSchema() forms.Schema {
+	return forms.Schema{
+		"name":     forms.String(&x.Name),
+		"age":      forms.IntOr(&x.Age, 0),
+		"password": forms.Secret(&x.Password),
+		"tags":     forms.StringsOr(&x.Tags, nil),
+	}
+}
+`
+
+	result, err := generate("app.go", source)
+	must.NoError(t, err)
+	must.Eq(t, exp, string(result))
+}
+
+func Test_generate_unsupported(t *testing.T) {
+	t.Parallel()
+
+	source := []byte(`package app
+
+//forms:generate
+type Signup struct {
+	When complex128 ` + "`form:\"when\"`" + `
+}
+`)
+
+	_, err := generate("app.go", source)
+	must.ErrorContains(t, err, "unsupported field type complex128")
+}
+
+func Test_generate_none(t *testing.T) {
+	t.Parallel()
+
+	_, err := generate("app.go", []byte("package app\n"))
+	must.ErrorContains(t, err, "no structs annotated")
+}
@@ -0,0 +1,70 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Command forms-gen generates reflection-free schema functions for structs
+// annotated with a "//forms:generate" comment. Each exported field of such a
+// struct with a `form` tag is bound to the parser matching its type, e.g.
+//
+//	//forms:generate
+//	type Signup struct {
+//		Name string `form:"name"`
+//		Age  int    `form:"age,optional"`
+//	}
+//
+// generates a method FormSchema with the signature func(*Signup) forms.Schema,
+// suitable for use with forms.Define, forms.Middleware, and forms.Register.
+// Fields marked optional use the Or variant of their parser, defaulting to the
+// zero value.
+//
+// The command is meant to be run by go generate from the file declaring the
+// structs,
+//
+//	//go:generate go run cattlecloud.net/go/forms/cmd/forms-gen
+//
+// and writes its output next to that file, with a "_forms.go" suffix.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	output := flag.String("output", "", "output file (default <file>_forms.go)")
+	flag.Parse()
+
+	input := os.Getenv("GOFILE")
+	if flag.NArg() > 0 {
+		input = flag.Arg(0)
+	}
+
+	if err := run(input, *output); err != nil {
+		fmt.Fprintln(os.Stderr, "forms-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(input, output string) error {
+	if input == "" {
+		return errors.New("no input file (run with go generate, or name a file)")
+	}
+
+	if output == "" {
+		output = strings.TrimSuffix(input, ".go") + "_forms.go"
+	}
+
+	source, err := os.ReadFile(input)
+	if err != nil {
+		return err
+	}
+
+	generated, err := generate(input, source)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(output, generated, 0o644)
+}
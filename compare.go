@@ -0,0 +1,110 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Rule is a comparison between the values of two fields, checked by the
+// Rules option once every field has been parsed.
+type Rule struct {
+	first, second string
+	relation      string
+	compare       func(a, b string) (bool, error)
+}
+
+// check reports whether data satisfies the rule. The rule is satisfied if
+// either field is missing, so that optional fields may be left empty.
+func (r *Rule) check(data url.Values) error {
+	a, b := data.Get(r.first), data.Get(r.second)
+	if a == "" || b == "" {
+		return nil
+	}
+
+	ok, err := r.compare(a, b)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		return fmt.Errorf("%w: %s must be %s %s", ErrComparison, r.first, r.relation, r.second)
+	}
+	return nil
+}
+
+// Rules causes each of rules to be checked after every field of the schema
+// has been parsed. A rule which is not satisfied fails as an error of its
+// second field. A rule is not checked if either of its fields failed to parse.
+func Rules(rules ...Rule) Option {
+	return func(o *options) {
+		o.rules = append(o.rules, rules...)
+	}
+}
+
+// timeLayouts are the formats accepted by Before, being those submitted by the
+// date, datetime-local, and time inputs of HTML, along with RFC 3339.
+var timeLayouts = []string{
+	time.RFC3339,
+	time.DateOnly,
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+	"15:04",
+	"15:04:05",
+}
+
+func parseTime(value string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q", value)
+}
+
+// Before is a Rule that the time in the field named first is strictly before
+// the time in the field named second. Times may be formatted as RFC 3339, or
+// as submitted by the date, datetime-local, or time inputs of HTML.
+func Before(first, second string) Rule {
+	return Rule{
+		first:    first,
+		second:   second,
+		relation: "before",
+		compare: func(a, b string) (bool, error) {
+			ta, err := parseTime(a)
+			if err != nil {
+				return false, err
+			}
+			tb, err := parseTime(b)
+			if err != nil {
+				return false, err
+			}
+			return ta.Before(tb), nil
+		},
+	}
+}
+
+// LessThan is a Rule that the number in the field named first is strictly
+// less than the number in the field named second.
+func LessThan(first, second string) Rule {
+	return Rule{
+		first:    first,
+		second:   second,
+		relation: "less than",
+		compare: func(a, b string) (bool, error) {
+			fa, err := strconv.ParseFloat(strings.TrimSpace(a), 64)
+			if err != nil {
+				return false, err
+			}
+			fb, err := strconv.ParseFloat(strings.TrimSpace(b), 64)
+			if err != nil {
+				return false, err
+			}
+			return fa < fb, nil
+		},
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Rules_Before(t *testing.T) {
+	t.Parallel()
+
+	var start, end string
+	schema := Schema{
+		"start_date": String(&start),
+		"end_date":   String(&end),
+	}
+	rules := Rules(Before("start_date", "end_date"))
+
+	err := ParseValues(url.Values{"start_date": {"2026-01-01"}, "end_date": {"2026-01-02"}}, schema, rules)
+	must.NoError(t, err)
+
+	err = ParseValues(url.Values{"start_date": {"2026-01-02T10:00"}, "end_date": {"2026-01-02T09:30"}}, schema, rules)
+	must.ErrorIs(t, err, ErrComparison)
+	must.EqError(t, err, "could not parse value: end_date: fields are out of order: start_date must be before end_date")
+
+	var fe *FieldError
+	must.True(t, errors.As(err, &fe))
+	must.Eq(t, "end_date", fe.Field)
+
+	err = ParseValues(url.Values{"start_date": {"soon"}, "end_date": {"2026-01-02"}}, schema, rules)
+	must.Error(t, err)
+}
+
+func Test_Rules_LessThan(t *testing.T) {
+	t.Parallel()
+
+	var lo, hi float64
+	schema := Schema{
+		"min_price": FloatOr(&lo, 0),
+		"max_price": FloatOr(&hi, 0),
+	}
+	rules := Rules(LessThan("min_price", "max_price"))
+
+	err := ParseValues(url.Values{"min_price": {"10"}, "max_price": {"99.5"}}, schema, rules)
+	must.NoError(t, err)
+
+	err = ParseValues(url.Values{"min_price": {"10"}, "max_price": {"10"}}, schema, rules)
+	must.ErrorIs(t, err, ErrComparison)
+
+	// a missing field is not compared
+	err = ParseValues(url.Values{"min_price": {"10"}}, schema, rules)
+	must.NoError(t, err)
+}
+
+func Test_Rules_skip_failed(t *testing.T) {
+	t.Parallel()
+
+	var lo, hi int
+	err := ParseValues(url.Values{"lo": {"ten"}, "hi": {"5"}}, Schema{
+		"lo": Int(&lo),
+		"hi": Int(&hi),
+	}, Rules(LessThan("lo", "hi")), CollectAllErrors())
+
+	var errs Errors
+	must.True(t, errors.As(err, &errs))
+	must.SliceLen(t, 1, errs)
+	must.Eq(t, "lo", errs[0].Field)
+}
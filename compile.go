@@ -0,0 +1,419 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A CompiledSchema is a Schema which has been checked for mistakes and
+// prepared for repeated parsing, with the parsers of its fields ordered and
+// classified ahead of time. Like the Schema it was compiled from, it writes
+// into the destinations its parsers were created with, so a CompiledSchema
+// must not be used by concurrent parses. A Definition is compiled once and
+// bound to new destinations for each parse, so it may be used concurrently.
+type CompiledSchema struct {
+	fields []compiledField // ordered by name
+	forms  []int           // indices of fields reading the complete form
+}
+
+type compiledField struct {
+	name      string
+	parser    Parser
+	form      formParser
+	context   ParserContext
+	policy    *MultiValuePolicy
+	multiple  bool
+	sensitive bool
+	label     string
+	brackets  string // name with "[]" appended, for multiple fields
+}
+
+// Compile checks schema for mistakes with Schema.Check, returning an error
+// wrapping ErrInvalidSchema if any are found, and prepares it for repeated
+// parsing.
+func Compile(schema Schema) (*CompiledSchema, error) {
+	if err := schema.Check(); err != nil {
+		return nil, err
+	}
+	return compile(schema), nil
+}
+
+func compile(schema Schema) *CompiledSchema {
+	c := &CompiledSchema{
+		fields: make([]compiledField, 0, len(schema)),
+	}
+	for name, parser := range schema {
+		c.fields = append(c.fields, compileField(name, parser))
+	}
+	slices.SortFunc(c.fields, func(a, b compiledField) int {
+		return cmp.Compare(a.name, b.name)
+	})
+	for i, f := range c.fields {
+		if f.form != nil {
+			c.forms = append(c.forms, i)
+		}
+	}
+	return c
+}
+
+func compileField(name string, parser Parser) compiledField {
+	f := unwrapField(name, parser)
+
+	d := describe(f.parser)
+	f.multiple = d.multiple && f.form == nil
+	if f.multiple {
+		f.brackets = name + "[]"
+	}
+	f.sensitive = f.sensitive || d.sensitive
+	return f
+}
+
+// unwrapField removes the wrappers of parser which are applied by the parse
+// loop itself, without describing the parser.
+func unwrapField(name string, parser Parser) compiledField {
+	f := compiledField{name: name}
+
+unwrap:
+	for {
+		switch w := parser.(type) {
+		case *policyParser:
+			if f.policy == nil {
+				f.policy = &w.policy
+			}
+			parser = w.Parser
+		case *sensitiveParser:
+			f.sensitive = true
+			parser = w.Parser
+		case *annotatedParser:
+			if f.label == "" {
+				f.label = w.meta.Label
+			}
+			parser = w.Parser
+		default:
+			break unwrap
+		}
+	}
+
+	f.parser = parser
+	f.form, _ = parser.(formParser)
+	f.context, _ = parser.(ParserContext)
+	return f
+}
+
+// rebind returns a CompiledSchema with the layout of c, parsing with the
+// parsers of schema, which must have been created by the same code as the
+// schema c was compiled from but with other destinations. It reports false if
+// schema does not match the layout of c.
+func (c *CompiledSchema) rebind(schema Schema) (*CompiledSchema, bool) {
+	if len(schema) != len(c.fields) {
+		return nil, false
+	}
+
+	fields := make([]compiledField, len(c.fields))
+	for i, layout := range c.fields {
+		parser, exists := schema[layout.name]
+		if !exists || parser == nil {
+			return nil, false
+		}
+		f := unwrapField(layout.name, parser)
+		if (f.form == nil) != (layout.form == nil) {
+			return nil, false
+		}
+		f.multiple, f.brackets = layout.multiple, layout.brackets
+		f.sensitive = f.sensitive || layout.sensitive
+		fields[i] = f
+	}
+	return &CompiledSchema{fields: fields, forms: c.forms}, true
+}
+
+func (f *compiledField) parse(ctx context.Context, data url.Values, o *options) error {
+	if f.form != nil {
+		return f.form.parseForm(ctx, f.name, data, o)
+	}
+
+	values := data[f.name]
+	if f.multiple {
+		// values may also be submitted in the name[] notation of PHP and
+		// many JavaScript form serializers
+		if extra := data[f.brackets]; len(extra) > 0 {
+			values = append(slices.Clip(values), extra...)
+		}
+	}
+
+	policy := o.multiple
+	if f.policy != nil {
+		policy = *f.policy
+	}
+	if len(values) > 1 && policy != ErrorOnMultiple && !f.multiple {
+		values = policy.apply(values)
+	}
+
+	if d, ok := f.parser.(dryRunner); ok && o.dryRun {
+		return d.dryRun(values)
+	}
+	if f.context != nil {
+		return f.context.ParseContext(ctx, values)
+	}
+	return f.parser.Parse(values)
+}
+
+// observe parses the field, recording any Warning it produces and reporting
+// the outcome to the observer, logger, and audit record of o.
+func (f *compiledField) observe(ctx context.Context, data url.Values, o *options) error {
+	var start time.Time
+	if o.observer != nil {
+		start = time.Now()
+	}
+
+	name := o.qualify(f.name)
+	err := f.parse(ctx, data, o)
+	if w, ok := asWarning(err); ok {
+		o.warn(name, w)
+		err = nil
+	}
+
+	if _, group := f.form.(*groupParser); group {
+		// a Group reports each of its fields itself
+		return err
+	}
+	if o.observer != nil {
+		o.observer(name, err, time.Since(start))
+	}
+	if o.logger != nil && err != nil {
+		f.log(ctx, o.logger, name, err)
+	}
+	if o.audit != nil {
+		af := f.audit(data, err)
+		af.Name = name
+		o.audit.Fields = append(o.audit.Fields, af)
+	}
+	return err
+}
+
+// log records the failure to parse the field, omitting the error message of
+// sensitive fields since it may contain the submitted value.
+func (f *compiledField) log(ctx context.Context, logger *slog.Logger, name string, err error) {
+	attrs := []slog.Attr{
+		slog.String("field", name),
+		slog.String("code", ErrorCode(err)),
+	}
+	if !f.sensitive {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	logger.LogAttrs(ctx, slog.LevelDebug, "form field failed to parse", attrs...)
+}
+
+// optionsPool avoids allocating options for every parse of a CompiledSchema.
+var optionsPool = sync.Pool{
+	New: func() any { return new(options) },
+}
+
+func (c *CompiledSchema) withOptions(opts []Option, fn func(*options) error) error {
+	o := optionsPool.Get().(*options)
+	defer optionsPool.Put(o)
+
+	*o = options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return fn(o)
+}
+
+// Parse is like the package level Parse, using the compiled schema.
+func (c *CompiledSchema) Parse(r *http.Request, opts ...Option) error {
+	return c.withOptions(opts, func(o *options) error {
+		if err := parseRequest(r, o); err != nil {
+			return err
+		}
+		return c.parse(r.Context(), r.Form, o)
+	})
+}
+
+// ParseValues is like the package level ParseValues, using the compiled schema.
+func (c *CompiledSchema) ParseValues(data url.Values, opts ...Option) error {
+	return c.ParseValuesContext(context.Background(), data, opts...)
+}
+
+// ParseValuesContext is like the package level ParseValuesContext, using the
+// compiled schema.
+func (c *CompiledSchema) ParseValuesContext(ctx context.Context, data url.Values, opts ...Option) error {
+	return c.withOptions(opts, func(o *options) error {
+		return c.parse(ctx, data, o)
+	})
+}
+
+// parse is the implementation of every parsing entry point. Fields are parsed
+// in order of name. By default the first field to fail is returned as an
+// error along with every missing field; if o.collect is set then every failure
+// is collected into an Errors, ordered by field name.
+func (c *CompiledSchema) parse(ctx context.Context, data url.Values, o *options) (err error) {
+	if o.audit != nil {
+		*o.audit = AuditRecord{}
+		defer func() { o.audit.Valid = err == nil }()
+	}
+
+	if o.maxFields > 0 && len(data) > o.maxFields {
+		return fmt.Errorf("%s: %w", ErrParseFailure.Error(), ErrTooManyFields)
+	}
+
+	if o.maxValueLen > 0 {
+		if key, found := longValue(data, o.maxValueLen); found {
+			err := fmt.Errorf("%w: limit is %d bytes", ErrValueTooLong, o.maxValueLen)
+			return Errors{{Field: key, Err: err}}
+		}
+	}
+
+	if len(o.wire) > 0 {
+		data = renameWire(data, o.wire)
+	}
+
+	if o.trim {
+		data = trimValues(data)
+	}
+
+	var errs Errors
+	var failed bool
+	var fail func(name, label string, err error)
+	fail = func(name, label string, err error) {
+		fe := &FieldError{Field: name, Label: label, Err: err}
+		switch nested := err.(type) {
+		case Errors:
+			// the errors of a Group are named within the group
+			for _, e := range nested {
+				fail(name, label, e)
+			}
+			return
+		case *FieldError:
+			fe = &FieldError{Field: groupName(name, nested.Field), Label: nested.Label, Err: nested.Err}
+		}
+		if !o.collect && !errors.Is(fe.Err, ErrNoValue) {
+			// only the first failure is kept, along with every missing field
+			if failed {
+				return
+			}
+			failed = true
+		}
+		errs = append(errs, fe)
+	}
+
+	// keys are visited in order so that the reported errors are stable
+	var keys []string
+	if o.strict || o.text {
+		keys = slices.Sorted(maps.Keys(data))
+	}
+
+	if o.rest != nil {
+		rest := make(url.Values)
+		for key, values := range data {
+			if !c.claimed(key) {
+				rest[key] = values
+			}
+		}
+		*o.rest = rest
+	}
+
+	if o.strict {
+		for _, key := range keys {
+			if c.claimed(key) {
+				continue
+			}
+			fail(key, "", ErrUnexpectedField)
+		}
+	}
+
+	if o.text {
+		invalid := false
+		for _, key := range keys {
+			if validText(key) && !slices.ContainsFunc(data[key], invalidText) {
+				continue
+			}
+			invalid = true
+			fail(key, "", ErrInvalidText)
+		}
+		if invalid && !o.collect {
+			// invalid text must not reach the destinations
+			return result(errs)
+		}
+	}
+
+	for i := range c.fields {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		f := &c.fields[i]
+		if err := f.observe(ctx, data, o); err != nil {
+			fail(f.name, f.label, err)
+		}
+	}
+
+	for _, rule := range o.rules {
+		if slices.ContainsFunc(errs, func(fe *FieldError) bool {
+			return fe.Field == rule.first || fe.Field == rule.second
+		}) {
+			// a field which failed to parse cannot be compared
+			continue
+		}
+		if err := rule.check(data); err != nil {
+			fail(rule.second, "", err)
+		}
+	}
+
+	if len(errs) == 0 && o.group == "" && !o.dryRun {
+		for _, d := range o.deferred {
+			if err := d.apply(ctx); err != nil {
+				fail(d.field, "", err)
+			}
+		}
+	}
+
+	return result(errs)
+}
+
+// result returns the error of a parse which found errs.
+func result(errs Errors) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	slices.SortStableFunc(errs, func(a, b *FieldError) int {
+		return cmp.Compare(a.Field, b.Field)
+	})
+	return errs
+}
+
+// claimed reports whether the form value named key is read by any parser of
+// the schema.
+func (c *CompiledSchema) claimed(key string) bool {
+	i, found := slices.BinarySearchFunc(c.fields, key, func(f compiledField, key string) int {
+		return cmp.Compare(f.name, key)
+	})
+	if found && c.fields[i].form == nil {
+		return true
+	}
+	if name, ok := strings.CutSuffix(key, "[]"); ok {
+		i, found := slices.BinarySearchFunc(c.fields, name, func(f compiledField, key string) int {
+			return cmp.Compare(f.name, key)
+		})
+		if found && c.fields[i].multiple {
+			return true
+		}
+	}
+	for _, i := range c.forms {
+		if c.fields[i].form.claims(c.fields[i].name, key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Compile(t *testing.T) {
+	t.Parallel()
+
+	var (
+		name string
+		age  int
+	)
+
+	compiled, err := Compile(Schema{
+		"name": String(&name),
+		"age":  IntOr(&age, 18),
+	})
+	must.NoError(t, err)
+
+	err = compiled.ParseValues(url.Values{"name": {"bob"}})
+	must.NoError(t, err)
+	must.Eq(t, "bob", name)
+	must.Eq(t, 18, age)
+
+	err = compiled.ParseValues(url.Values{"name": {"alice"}, "age": {"30"}})
+	must.NoError(t, err)
+	must.Eq(t, "alice", name)
+	must.Eq(t, 30, age)
+
+	err = compiled.ParseValues(url.Values{"name": {"carol"}, "x": {"1"}}, Strict())
+	must.ErrorIs(t, err, ErrUnexpectedField)
+
+	err = compiled.Parse(postForm(t, url.Values{"name": {"dave"}}))
+	must.NoError(t, err)
+	must.Eq(t, "dave", name)
+}
+
+func Test_Compile_invalid(t *testing.T) {
+	t.Parallel()
+
+	var name string
+
+	_, err := Compile(Schema{"": String(&name)})
+	must.ErrorIs(t, err, ErrInvalidSchema)
+
+	_, err = Compile(Schema{"name": nil})
+	must.ErrorIs(t, err, ErrInvalidSchema)
+}
+
+func Test_Compile_order(t *testing.T) {
+	t.Parallel()
+
+	var a, b, c int
+	compiled, err := Compile(Schema{
+		"c": Int(&c),
+		"a": Int(&a),
+		"b": Int(&b),
+	})
+	must.NoError(t, err)
+
+	// fields are always parsed, and therefore fail, in order of name
+	for range 10 {
+		err = compiled.ParseValues(url.Values{"a": {"x"}, "b": {"x"}, "c": {"x"}})
+		must.StrHasPrefix(t, "could not parse value: a: ", err.Error())
+	}
+}
+
+var benchmarkData = url.Values{
+	"name":    {"bob"},
+	"age":     {"42"},
+	"worth":   {"3.5"},
+	"admin":   {"on"},
+	"country": {"US"},
+}
+
+func BenchmarkParseValues(b *testing.B) {
+	var (
+		name, country string
+		age           int
+		worth         float64
+		admin         bool
+	)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = ParseValues(benchmarkData, Schema{
+			"name":    String(&name),
+			"age":     Int(&age),
+			"worth":   Float(&worth),
+			"admin":   Bool(&admin),
+			"country": CountryCode(&country),
+		})
+	}
+}
+
+func BenchmarkCompiledSchema_ParseValues(b *testing.B) {
+	var (
+		name, country string
+		age           int
+		worth         float64
+		admin         bool
+	)
+
+	compiled, err := Compile(Schema{
+		"name":    String(&name),
+		"age":     Int(&age),
+		"worth":   Float(&worth),
+		"admin":   Bool(&admin),
+		"country": CountryCode(&country),
+	})
+	must.NoError(b, err)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = compiled.ParseValues(benchmarkData)
+	}
+}
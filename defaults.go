@@ -0,0 +1,162 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"os"
+)
+
+type defaultFuncParser[T any] struct {
+	Parser
+	fn          func() T
+	source      string // describes where the default comes from
+	alt         any
+	destination *T
+}
+
+func (p *defaultFuncParser[T]) describe() field {
+	f := describe(p.Parser)
+	f.required = false
+	f.alt = p.alt
+	f.constraints = append(f.constraints[:len(f.constraints):len(f.constraints)], p.source)
+	return f
+}
+
+func (p *defaultFuncParser[T]) destinations() []any {
+	return destinations(p.Parser)
+}
+
+func (p *defaultFuncParser[T]) Parse(values []string) error {
+	if len(values) == 0 {
+		*p.destination = p.fn()
+		return nil
+	}
+	return p.Parser.Parse(values)
+}
+
+// StringOrFunc is used to extract a form data value into a Go string. If the
+// value is missing, then the value returned by fn is used instead. Unlike
+// StringOr, fn is called each time the form is parsed.
+func StringOrFunc[T StringType](s *T, fn func() T) Parser {
+	return &defaultFuncParser[T]{
+		Parser:      String(s),
+		fn:          fn,
+		source:      "default computed when parsed",
+		destination: s,
+	}
+}
+
+// IntOrFunc is used to extract a form data value into a Go int. If the value
+// is missing, then the value returned by fn is used instead. Unlike IntOr, fn
+// is called each time the form is parsed.
+func IntOrFunc[T IntType](i *T, fn func() T) Parser {
+	return &defaultFuncParser[T]{
+		Parser:      Int(i),
+		fn:          fn,
+		source:      "default computed when parsed",
+		destination: i,
+	}
+}
+
+// FloatOrFunc is used to extract a form data value into a Go float64. If the
+// value is missing, then the value returned by fn is used instead. Unlike
+// FloatOr, fn is called each time the form is parsed.
+func FloatOrFunc(f *float64, fn func() float64) Parser {
+	return &defaultFuncParser[float64]{
+		Parser:      Float(f),
+		fn:          fn,
+		source:      "default computed when parsed",
+		destination: f,
+	}
+}
+
+// BoolOrFunc is used to extract a form data value into a Go bool. If the value
+// is missing, then the value returned by fn is used instead. Unlike BoolOr, fn
+// is called each time the form is parsed.
+func BoolOrFunc(b *bool, fn func() bool) Parser {
+	return &defaultFuncParser[bool]{
+		Parser:      Bool(b),
+		fn:          fn,
+		source:      "default computed when parsed",
+		destination: b,
+	}
+}
+
+// fromEnv returns a function which reads the environment variable named key,
+// converting it with the Parser created by parser, so that the variable is
+// accepted in the same format as the form value. If the variable is unset,
+// empty, or cannot be converted, fallback is returned instead.
+func fromEnv[T any](key string, fallback T, parser func(*T) Parser) func() T {
+	return func() T {
+		value := os.Getenv(key)
+		if value == "" {
+			return fallback
+		}
+		var v T
+		if err := parser(&v).Parse([]string{value}); err != nil {
+			return fallback
+		}
+		return v
+	}
+}
+
+func envSource(key string) string {
+	return "default from environment variable " + key
+}
+
+// StringOrEnv is used to extract a form data value into a Go string. If the
+// value is missing, then the value of the environment variable named key is
+// used instead, or fallback if that variable is unset or empty. The variable
+// is read each time the form is parsed.
+func StringOrEnv[T StringType](s *T, key string, fallback T) Parser {
+	return &defaultFuncParser[T]{
+		Parser:      String(s),
+		fn:          fromEnv(key, fallback, String[T]),
+		source:      envSource(key),
+		alt:         fallback,
+		destination: s,
+	}
+}
+
+// IntOrEnv is used to extract a form data value into a Go int. If the value
+// is missing, then the value of the environment variable named key is used
+// instead, or fallback if that variable is unset, empty, or not an integer.
+// The variable is read each time the form is parsed.
+func IntOrEnv[T IntType](i *T, key string, fallback T) Parser {
+	return &defaultFuncParser[T]{
+		Parser:      Int(i),
+		fn:          fromEnv(key, fallback, Int[T]),
+		source:      envSource(key),
+		alt:         fallback,
+		destination: i,
+	}
+}
+
+// FloatOrEnv is used to extract a form data value into a Go float64. If the
+// value is missing, then the value of the environment variable named key is
+// used instead, or fallback if that variable is unset, empty, or not a number.
+// The variable is read each time the form is parsed.
+func FloatOrEnv(f *float64, key string, fallback float64) Parser {
+	return &defaultFuncParser[float64]{
+		Parser:      Float(f),
+		fn:          fromEnv(key, fallback, Float),
+		source:      envSource(key),
+		alt:         fallback,
+		destination: f,
+	}
+}
+
+// BoolOrEnv is used to extract a form data value into a Go bool. If the value
+// is missing, then the value of the environment variable named key is used
+// instead, or fallback if that variable is unset, empty, or not a boolean.
+// The variable is read each time the form is parsed.
+func BoolOrEnv(b *bool, key string, fallback bool) Parser {
+	return &defaultFuncParser[bool]{
+		Parser:      Bool(b),
+		fn:          fromEnv(key, fallback, Bool),
+		source:      envSource(key),
+		alt:         fallback,
+		destination: b,
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_OrFunc_missing(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	var (
+		owner   string
+		count   int
+		ratio   float64
+		enabled bool
+	)
+
+	schema := Schema{
+		"owner":   StringOrFunc(&owner, func() string { calls++; return "alice" }),
+		"count":   IntOrFunc(&count, func() int { return 3 }),
+		"ratio":   FloatOrFunc(&ratio, func() float64 { return 0.5 }),
+		"enabled": BoolOrFunc(&enabled, func() bool { return true }),
+	}
+	must.Zero(t, calls)
+
+	err := ParseValues(url.Values{}, schema)
+	must.NoError(t, err)
+	must.Eq(t, "alice", owner)
+	must.Eq(t, 3, count)
+	must.Eq(t, 0.5, ratio)
+	must.True(t, enabled)
+
+	err = ParseValues(url.Values{}, schema)
+	must.NoError(t, err)
+	must.Eq(t, 2, calls)
+}
+
+func Test_Parse_OrFunc_present(t *testing.T) {
+	t.Parallel()
+
+	var (
+		owner string
+		count int
+	)
+
+	schema := Schema{
+		"owner": StringOrFunc(&owner, func() string { panic("not called") }),
+		"count": IntOrFunc(&count, func() int { panic("not called") }),
+	}
+
+	err := ParseValues(url.Values{"owner": {"bob"}, "count": {"7"}}, schema)
+	must.NoError(t, err)
+	must.Eq(t, "bob", owner)
+	must.Eq(t, 7, count)
+
+	err = ParseValues(url.Values{"owner": {"bob"}, "count": {"seven"}}, schema)
+	must.Error(t, err)
+}
+
+func Test_OrFunc_describe(t *testing.T) {
+	t.Parallel()
+
+	var owner string
+	f := describe(StringOrFunc(&owner, func() string { return "alice" }))
+	must.False(t, f.required)
+	must.Eq(t, "string", f.kind)
+	must.Eq(t, []string{"default computed when parsed"}, f.constraints)
+}
+
+func Test_Parse_OrEnv(t *testing.T) {
+	t.Setenv("FORMS_TEST_REGION", "us-east-1")
+	t.Setenv("FORMS_TEST_SEATS", "5")
+	t.Setenv("FORMS_TEST_RATE", "bogus")
+	t.Setenv("FORMS_TEST_TRIAL", "on")
+
+	var (
+		region string
+		plan   string
+		seats  int
+		rate   float64
+		trial  bool
+	)
+
+	schema := Schema{
+		"region": StringOrEnv(&region, "FORMS_TEST_REGION", "us-west-2"),
+		"plan":   StringOrEnv(&plan, "FORMS_TEST_PLAN", "free"),
+		"seats":  IntOrEnv(&seats, "FORMS_TEST_SEATS", 1),
+		"rate":   FloatOrEnv(&rate, "FORMS_TEST_RATE", 1.5),
+		"trial":  BoolOrEnv(&trial, "FORMS_TEST_TRIAL", false),
+	}
+
+	err := ParseValues(url.Values{}, schema)
+	must.NoError(t, err)
+	must.Eq(t, "us-east-1", region)
+	must.Eq(t, "free", plan)
+	must.Eq(t, 5, seats)
+	must.Eq(t, 1.5, rate)
+	must.True(t, trial)
+
+	err = ParseValues(url.Values{"region": {"eu-west-1"}, "seats": {"10"}}, schema)
+	must.NoError(t, err)
+	must.Eq(t, "eu-west-1", region)
+	must.Eq(t, 10, seats)
+}
+
+func Test_OrEnv_describe(t *testing.T) {
+	t.Parallel()
+
+	var region string
+	f := describe(StringOrEnv(&region, "REGION", "us-west-2"))
+	must.False(t, f.required)
+	must.Eq(t, "us-west-2", f.alt)
+	must.Eq(t, []string{"default from environment variable REGION"}, f.constraints)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// A Definition describes how a form is parsed into a value of type T. Unlike a
+// Schema, whose parsers capture pointers to their destinations, a Definition
+// binds to a new T each time a form is parsed, so it may be declared once (e.g.
+// at package level) and shared by concurrent requests.
+//
+// The schema of a Definition is checked and compiled by its first parse, and
+// the compiled layout is reused by later parses with the parsers bound to
+// each new T. If the schema is invalid, every parse fails with an error
+// wrapping ErrInvalidSchema.
+type Definition[T any] struct {
+	bind func(*T) Schema
+
+	once   sync.Once
+	layout *CompiledSchema
+	err    error
+}
+
+// Define creates a Definition from bind, which is called for every parse with
+// a pointer to a new T and must return the Schema that parses the form into
+// the fields of that T.
+//
+//	var signup = forms.Define(func(s *Signup) forms.Schema {
+//		return forms.Schema{
+//			"name": forms.String(&s.Name),
+//			"age":  forms.IntOr(&s.Age, 18),
+//		}
+//	})
+func Define[T any](bind func(*T) Schema) *Definition[T] {
+	return &Definition[T]{bind: bind}
+}
+
+// Schema returns the Schema of the definition bound to the fields of form.
+func (d *Definition[T]) Schema(form *T) Schema {
+	return d.bind(form)
+}
+
+// compile binds form, returning its Schema along with the schema compiled
+// with the layout of the Definition.
+func (d *Definition[T]) compile(form *T) (Schema, *CompiledSchema, error) {
+	schema := d.bind(form)
+	d.once.Do(func() {
+		d.layout, d.err = Compile(schema)
+	})
+	if d.err != nil {
+		return nil, nil, d.err
+	}
+	if c, ok := d.layout.rebind(schema); ok {
+		return schema, c, nil
+	}
+	// the schema depends on the bound value, so has its own layout
+	c, err := Compile(schema)
+	return schema, c, err
+}
+
+// Parse parses the HTTP form values of r into a new T.
+func (d *Definition[T]) Parse(r *http.Request, opts ...Option) (*T, error) {
+	form := new(T)
+	schema, c, err := d.compile(form)
+	if err != nil {
+		return form, err
+	}
+	if err := c.Parse(r, opts...); err != nil {
+		return form, err
+	}
+	return form, validateStruct(form, schema, newOptions(opts))
+}
+
+// ParseBody parses the urlencoded body of r into a new T, as
+// CompiledSchema.ParseBody does.
+func (d *Definition[T]) ParseBody(r *http.Request, opts ...Option) (*T, error) {
+	form := new(T)
+	schema, c, err := d.compile(form)
+	if err != nil {
+		return form, err
+	}
+	if err := c.ParseBody(r, opts...); err != nil {
+		return form, err
+	}
+	return form, validateStruct(form, schema, newOptions(opts))
+}
+
+// ParseValues parses data into a new T.
+func (d *Definition[T]) ParseValues(data url.Values, opts ...Option) (*T, error) {
+	return d.ParseValuesContext(context.Background(), data, opts...)
+}
+
+// ParseValuesContext parses data into a new T, giving ctx to any ParserContext
+// implementations in the schema.
+func (d *Definition[T]) ParseValuesContext(ctx context.Context, data url.Values, opts ...Option) (*T, error) {
+	form := new(T)
+	schema, c, err := d.compile(form)
+	if err != nil {
+		return form, err
+	}
+	if err := c.ParseValuesContext(ctx, data, opts...); err != nil {
+		return form, err
+	}
+	return form, validateStruct(form, schema, newOptions(opts))
+}
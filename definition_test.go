@@ -0,0 +1,107 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+var signupDefinition = Define(bindSignup)
+
+func Test_Definition_ParseValues(t *testing.T) {
+	t.Parallel()
+
+	form, err := signupDefinition.ParseValues(url.Values{"name": {"bob"}})
+	must.NoError(t, err)
+	must.Eq(t, &signup{Name: "bob", Age: 18}, form)
+
+	_, err = signupDefinition.ParseValues(url.Values{})
+	must.ErrorIs(t, err, ErrNoValue)
+}
+
+func Test_Definition_Parse(t *testing.T) {
+	t.Parallel()
+
+	form, err := signupDefinition.Parse(postForm(t, url.Values{
+		"name": {"alice"},
+		"age":  {"30"},
+	}))
+	must.NoError(t, err)
+	must.Eq(t, &signup{Name: "alice", Age: 30}, form)
+}
+
+func Test_Definition_concurrent(t *testing.T) {
+	t.Parallel()
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Go(func() {
+			age := strconv.Itoa(i)
+			form, err := signupDefinition.ParseValues(url.Values{
+				"name": {"user" + age},
+				"age":  {age},
+			})
+			must.NoError(t, err)
+			must.Eq(t, &signup{Name: "user" + age, Age: i}, form)
+		})
+	}
+	wg.Wait()
+}
+
+func Test_Definition_invalid(t *testing.T) {
+	t.Parallel()
+
+	shared := Define(func(s *signup) Schema {
+		return Schema{
+			"name":  String(&s.Name),
+			"alias": String(&s.Name),
+		}
+	})
+	for range 2 {
+		_, err := shared.ParseValues(url.Values{"name": {"bob"}, "alias": {"bob"}})
+		must.ErrorIs(t, err, ErrInvalidSchema)
+	}
+}
+
+func Test_Definition_dynamic(t *testing.T) {
+	t.Parallel()
+
+	// a schema which depends on the bound value cannot reuse the layout
+	calls := 0
+	dynamic := Define(func(s *signup) Schema {
+		calls++
+		schema := Schema{"name": String(&s.Name)}
+		if calls > 1 {
+			schema["age"] = Int(&s.Age)
+		}
+		return schema
+	})
+
+	form, err := dynamic.ParseValues(url.Values{"name": {"bob"}})
+	must.NoError(t, err)
+	must.Eq(t, &signup{Name: "bob"}, form)
+
+	form, err = dynamic.ParseValues(url.Values{"name": {"bob"}, "age": {"30"}})
+	must.NoError(t, err)
+	must.Eq(t, &signup{Name: "bob", Age: 30}, form)
+
+	_, err = dynamic.ParseValues(url.Values{"name": {"bob"}})
+	must.ErrorIs(t, err, ErrNoValue)
+}
+
+func Test_Definition_ParseBody(t *testing.T) {
+	t.Parallel()
+
+	form, err := signupDefinition.ParseBody(postForm(t, url.Values{
+		"name": {"alice"},
+		"age":  {"30"},
+	}))
+	must.NoError(t, err)
+	must.Eq(t, &signup{Name: "alice", Age: 30}, form)
+}
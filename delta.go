@@ -0,0 +1,70 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"reflect"
+)
+
+// A Change describes a field whose parsed value differs from its current
+// value, as reported by Definition.ParseDelta. For fields with more than one
+// destination, such as IntRangePair, Old and New are each a []any.
+type Change struct {
+	Old any
+	New any
+}
+
+// values returns the values pointed to by the destinations of p.
+func values(p Parser) any {
+	ds := destinations(p)
+	vs := make([]any, 0, len(ds))
+	for _, d := range ds {
+		v := reflect.ValueOf(d)
+		if v.Kind() == reflect.Pointer && !v.IsNil() {
+			vs = append(vs, v.Elem().Interface())
+		}
+	}
+	switch len(vs) {
+	case 0:
+		return nil
+	case 1:
+		return vs[0]
+	default:
+		return vs
+	}
+}
+
+// ParseDelta parses data into a new T, as ParseValues does, and reports each
+// field whose parsed value differs from its value in current, keyed by field
+// name. This allows an edit form handler to write only the fields which were
+// changed, or to record them in an audit log. Fields whose parsers have no
+// destination, such as Honeypot, are never reported.
+func (d *Definition[T]) ParseDelta(data url.Values, current T, opts ...Option) (*T, map[string]Change, error) {
+	form := new(T)
+	schema, c, err := d.compile(form)
+	if err != nil {
+		return form, nil, err
+	}
+	if err := c.ParseValues(data, opts...); err != nil {
+		return form, nil, err
+	}
+	if err := validateStruct(form, schema, newOptions(opts)); err != nil {
+		return form, nil, err
+	}
+
+	// binding may set default values, so current is restored afterwards
+	old := new(T)
+	previous := d.bind(old)
+	*old = current
+
+	changes := make(map[string]Change)
+	for name, p := range schema {
+		before, after := values(previous[name]), values(p)
+		if !reflect.DeepEqual(before, after) {
+			changes[name] = Change{Old: before, New: after}
+		}
+	}
+	return form, changes, nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+type profile struct {
+	Name   string
+	Age    int
+	Tags   []string
+	Lo, Hi int
+}
+
+var profileDefinition = Define(func(p *profile) Schema {
+	return Schema{
+		"name":  String(&p.Name),
+		"age":   IntOr(&p.Age, 18),
+		"tags":  StringsOr(&p.Tags, nil),
+		"range": IntRangePairOr(&p.Lo, &p.Hi, 0, 10),
+		"trap":  Honeypot(),
+	}
+})
+
+func Test_Definition_ParseDelta(t *testing.T) {
+	t.Parallel()
+
+	current := profile{Name: "bob", Age: 30, Tags: []string{"a"}, Lo: 0, Hi: 10}
+	form, changes, err := profileDefinition.ParseDelta(url.Values{
+		"name":  {"bob"},
+		"tags":  {"a", "b"},
+		"range": {"0-10"},
+		"trap":  {""},
+	}, current)
+	must.NoError(t, err)
+	must.Eq(t, &profile{Name: "bob", Age: 18, Tags: []string{"a", "b"}, Lo: 0, Hi: 10}, form)
+	must.Eq(t, map[string]Change{
+		"age":  {Old: 30, New: 18},
+		"tags": {Old: []string{"a"}, New: []string{"a", "b"}},
+	}, changes)
+}
+
+func Test_Definition_ParseDelta_multiple_destinations(t *testing.T) {
+	t.Parallel()
+
+	current := profile{Name: "bob", Age: 18, Lo: 0, Hi: 10}
+	_, changes, err := profileDefinition.ParseDelta(url.Values{
+		"name":  {"bob"},
+		"range": {"5-10"},
+		"trap":  {""},
+	}, current)
+	must.NoError(t, err)
+	must.Eq(t, map[string]Change{
+		"range": {Old: []any{0, 10}, New: []any{5, 10}},
+	}, changes)
+}
+
+func Test_Definition_ParseDelta_error(t *testing.T) {
+	t.Parallel()
+
+	_, changes, err := profileDefinition.ParseDelta(url.Values{}, profile{})
+	must.ErrorIs(t, err, ErrNoValue)
+	must.Nil(t, changes)
+}
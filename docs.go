@@ -0,0 +1,171 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// A field describes a Parser, independent of any form values it may parse.
+type field struct {
+	kind        string
+	required    bool
+	multiple    bool
+	sensitive   bool
+	alt         any
+	constraints []string
+	meta        Metadata
+}
+
+// A describer is a Parser capable of describing itself.
+type describer interface {
+	describe() field
+}
+
+func describe(p Parser) field {
+	if d, ok := p.(describer); ok {
+		return d.describe()
+	}
+	return field{kind: "custom", required: true}
+}
+
+// FieldInfo describes one field of a Schema, as reported by Schema.Fields.
+type FieldInfo struct {
+	// Name is the name of the field in the form data.
+	Name string
+
+	// Kind is a short description of the type of value parsed, e.g. "int".
+	Kind string
+
+	// Required indicates whether a missing value causes an error.
+	Required bool
+
+	// Multiple indicates whether the field accepts more than one value.
+	Multiple bool
+
+	// Sensitive indicates whether the value of the field should be kept out
+	// of logs and error messages.
+	Sensitive bool
+
+	// Default is the value used when an optional field is missing. It is
+	// always nil for required and sensitive fields.
+	Default any
+
+	// Constraints are human-readable descriptions of restrictions applied to
+	// the value, e.g. "between 1 and 10".
+	Constraints []string
+
+	// Metadata is the presentational information attached with Annotate.
+	Metadata Metadata
+}
+
+// Fields describes each field of s, in order of name. This is the same
+// information rendered by Markdown, and may be used to generate client-side
+// validation or documentation from the schema used by the server.
+func (s Schema) Fields() []FieldInfo {
+	fields := make([]FieldInfo, 0, len(s))
+	for _, name := range slices.Sorted(maps.Keys(s)) {
+		f := describe(s[name])
+
+		info := FieldInfo{
+			Name:        name,
+			Kind:        f.kind,
+			Required:    f.required,
+			Multiple:    f.multiple,
+			Sensitive:   f.sensitive,
+			Constraints: slices.Clone(f.constraints),
+			Metadata:    f.meta,
+		}
+		if !f.required && !f.sensitive {
+			info.Default = f.alt
+		}
+
+		fields = append(fields, info)
+	}
+	return fields
+}
+
+// Markdown writes human-readable documentation of the fields of schema to w,
+// formatted as a Markdown table. Fields are listed in order of name, along with
+// their label, type, whether they are required, their default value if they
+// are not required, and any help text and constraints applied to their values.
+// The table is followed by the catalog of error codes which may be produced
+// while parsing.
+func Markdown(w io.Writer, schema Schema) error {
+	buf := bufio.NewWriter(w)
+
+	_, _ = buf.WriteString("| Field | Type | Required | Default | Constraints |\n")
+	_, _ = buf.WriteString("|-------|------|----------|---------|-------------|\n")
+
+	for _, f := range schema.Fields() {
+		required, fallback := "yes", ""
+		if !f.Required {
+			required, fallback = "no", formatValue(f.Default)
+		}
+
+		name := "`" + f.Name + "`"
+		if f.Metadata.Label != "" {
+			name += " (" + f.Metadata.Label + ")"
+		}
+
+		constraints := f.Constraints
+		if f.Metadata.Help != "" {
+			constraints = append([]string{f.Metadata.Help}, constraints...)
+		}
+
+		_, _ = fmt.Fprintf(buf, "| %s | %s | %s | %s | %s |\n",
+			markdownEscape(name),
+			f.Kind,
+			required,
+			markdownEscape(fallback),
+			markdownEscape(strings.Join(constraints, "; ")),
+		)
+	}
+
+	_, _ = buf.WriteString("\n| Error Code | Description |\n")
+	_, _ = buf.WriteString("|------------|-------------|\n")
+
+	for _, entry := range catalog {
+		_, _ = fmt.Fprintf(buf, "| `%s` | %s |\n", entry.Code, markdownEscape(entry.Description))
+	}
+
+	return buf.Flush()
+}
+
+// formatValue renders v for display, listing the elements of slices and maps
+// rather than using their Go syntax.
+func formatValue(v any) string {
+	if v == nil {
+		return ""
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice:
+		elements := make([]string, 0, rv.Len())
+		for i := range rv.Len() {
+			elements = append(elements, fmt.Sprint(rv.Index(i)))
+		}
+		return strings.Join(elements, ", ")
+	case reflect.Map:
+		elements := make([]string, 0, rv.Len())
+		for iter := rv.MapRange(); iter.Next(); {
+			elements = append(elements, fmt.Sprintf("%v=%v", iter.Key(), iter.Value()))
+		}
+		slices.Sort(elements)
+		return strings.Join(elements, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func markdownEscape(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shoenig/go-conceal"
+	"github.com/shoenig/test/must"
+)
+
+func Test_Markdown(t *testing.T) {
+	t.Parallel()
+
+	var (
+		name     string
+		age      int
+		aliases  []string
+		password *conceal.Text
+		meta     map[string]string
+		lo, hi   int
+	)
+
+	schema := Schema{
+		"name":     String(&name),
+		"age":      IntOr(&age, 21),
+		"aliases":  StringsOr(&aliases, []string{"a", "b"}),
+		"password": Secret(&password),
+		"meta":     MapOr(&meta, map[string]string{"k": "v|w"}),
+		"price":    IntRangePair(&lo, &hi),
+	}
+
+	var sb strings.Builder
+	err := Markdown(&sb, schema)
+	must.NoError(t, err)
+
+	exp := strings.Join([]string{
+		"| Field | Type | Required | Default | Constraints |",
+		"|-------|------|----------|---------|-------------|",
+		"| `age` | int | no | 21 |  |",
+		"| `aliases` | strings | no | a, b |  |",
+		"| `meta` | map | no | k=v\\|w | submitted as name.key or name[key] |",
+		"| `name` | string | yes |  |  |",
+		"| `password` | secret | yes |  |  |",
+		"| `price` | int range | yes |  | submitted as name (lo-hi) or name_min and name_max; lo <= hi |",
+		"",
+		"| Error Code | Description |",
+		"|------------|-------------|",
+		"",
+	}, "\n")
+	must.StrHasPrefix(t, exp, sb.String())
+	must.StrContains(t, sb.String(), "| `no_value` | A required field was not submitted. |\n")
+}
+
+func Test_Schema_Fields(t *testing.T) {
+	t.Parallel()
+
+	var (
+		name     string
+		age      int
+		aliases  []string
+		password *conceal.Text
+		port     uint16
+	)
+
+	schema := Schema{
+		"name":     String(&name),
+		"age":      IntOr(&age, 21),
+		"aliases":  Strings(&aliases),
+		"password": Secret(&password),
+		"custom":   &uniqueParser{taken: "root"},
+		"port":     UnprivilegedPort(&port),
+	}
+
+	fields := schema.Fields()
+	must.SliceLen(t, 6, fields)
+	must.Eq(t, []string{"age", "aliases", "custom", "name", "password", "port"}, []string{
+		fields[0].Name, fields[1].Name, fields[2].Name, fields[3].Name, fields[4].Name, fields[5].Name,
+	})
+
+	must.Eq(t, FieldInfo{Name: "age", Kind: "int", Default: 21}, fields[0])
+	must.Eq(t, FieldInfo{Name: "aliases", Kind: "strings", Required: true, Multiple: true}, fields[1])
+	must.Eq(t, FieldInfo{Name: "custom", Kind: "custom", Required: true}, fields[2])
+	must.Eq(t, FieldInfo{Name: "password", Kind: "secret", Required: true, Sensitive: true}, fields[4])
+	must.Eq(t, []string{"between 1024 and 65535"}, fields[5].Constraints)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"encoding"
+	"encoding/base64"
+)
+
+type binaryParser struct {
+	decode      func(string) ([]byte, error)
+	destination encoding.BinaryUnmarshaler
+}
+
+func (p *binaryParser) describe() field {
+	return field{kind: "binary", required: true}
+}
+
+func (p *binaryParser) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *binaryParser) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0:
+		return ErrNoValue
+	}
+
+	b, err := p.decode(values[0])
+	if err != nil {
+		return err
+	}
+	return p.destination.UnmarshalBinary(b)
+}
+
+// Binary is used to extract a form data value into any Go type implementing
+// encoding.BinaryUnmarshaler. The value is converted into bytes by decode, e.g.
+// hex.DecodeString or base64.RawURLEncoding.DecodeString, then given to the
+// UnmarshalBinary method of b. If decode is nil, the value is decoded as
+// standard base64. If the value is missing, cannot be decoded, or is rejected
+// by UnmarshalBinary, then an error is returned during parsing.
+func Binary(b encoding.BinaryUnmarshaler, decode func(string) ([]byte, error)) Parser {
+	if decode == nil {
+		decode = base64.StdEncoding.DecodeString
+	}
+	return &binaryParser{
+		decode:      decode,
+		destination: b,
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+type keyID [4]byte
+
+func (k *keyID) UnmarshalBinary(b []byte) error {
+	if len(b) != len(k) {
+		return errors.New("key id must be 4 bytes")
+	}
+	copy(k[:], b)
+	return nil
+}
+
+func Test_Parse_Binary(t *testing.T) {
+	t.Parallel()
+
+	var hexID, b64ID keyID
+	err := ParseValues(url.Values{"hex": {"deadbeef"}, "b64": {"3q2+7w=="}}, Schema{
+		"hex": Binary(&hexID, hex.DecodeString),
+		"b64": Binary(&b64ID, nil),
+	})
+	must.NoError(t, err)
+	must.Eq(t, keyID{0xde, 0xad, 0xbe, 0xef}, hexID)
+	must.Eq(t, keyID{0xde, 0xad, 0xbe, 0xef}, b64ID)
+}
+
+func Test_Parse_Binary_invalid(t *testing.T) {
+	t.Parallel()
+
+	var id keyID
+	err := ParseValues(url.Values{"id": {"not hex"}}, Schema{
+		"id": Binary(&id, hex.DecodeString),
+	})
+	must.Error(t, err)
+
+	err = ParseValues(url.Values{"id": {"dead"}}, Schema{
+		"id": Binary(&id, hex.DecodeString),
+	})
+	must.EqError(t, err, "could not parse value: id: key id must be 4 bytes")
+
+	err = ParseValues(url.Values{}, Schema{
+		"id": Binary(&id, hex.DecodeString),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
@@ -0,0 +1,315 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrNoValue         = errors.New("expected value to exist")
+	ErrMulitpleValues  = errors.New("expected only one value to exist")
+	ErrFieldNotPresent = errors.New("requested field does not exist")
+	ErrParseFailure    = errors.New("could not parse value")
+	ErrInvalidRange    = errors.New("range lower bound exceeds upper bound")
+	ErrOutOfRange      = errors.New("value is out of range")
+	ErrUnknownUnit     = errors.New("unrecognized unit")
+	ErrCardNumber      = errors.New("invalid card number")
+	ErrUnknownCode     = errors.New("unrecognized code")
+	ErrPasswordPolicy  = errors.New("password does not satisfy policy")
+	ErrNoForm          = errors.New("no parsed form in context")
+	ErrNotRegistered   = errors.New("no schema registered for type")
+	ErrUnexpectedField = errors.New("field is not part of the schema")
+	ErrTooManyFields   = errors.New("form contains too many fields")
+	ErrBodyTooLarge    = errors.New("request body too large")
+	ErrInvalidSchema   = errors.New("invalid schema")
+	ErrInvalidText     = errors.New("value contains invalid text")
+	ErrSpamSuspected   = errors.New("submission suspected to be spam")
+	ErrInvalidToken    = errors.New("invalid form token")
+	ErrTokenExpired    = errors.New("form token has expired")
+	ErrTokenUsed       = errors.New("form token has already been used")
+	ErrUnknownName     = errors.New("value is not a recognized name")
+	ErrFileTooLarge    = errors.New("file exceeds size limit")
+	ErrFileType        = errors.New("file type is not allowed")
+	ErrTooManyFiles    = errors.New("too many files")
+	ErrInvalidChoice   = errors.New("value is not one of the choices")
+	ErrComparison      = errors.New("fields are out of order")
+	ErrLength          = errors.New("value has an invalid length")
+	ErrPattern         = errors.New("value does not match the pattern")
+	ErrUnsupportedType = errors.New("destination type is not supported")
+	ErrValueTooLong    = errors.New("value exceeds length limit")
+	ErrValidation      = errors.New("failed validation")
+	ErrTooManyValues   = errors.New("too many values")
+)
+
+// A FieldError describes the failure to parse a single field of a form.
+type FieldError struct {
+	Field string
+	Label string
+	Err   error
+}
+
+// Error describes the failure, naming the field by its Label if it has one.
+func (e *FieldError) Error() string {
+	if e.Label != "" {
+		return e.Label + ": " + e.Err.Error()
+	}
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Errors is the collection of the FieldErrors which occurred while parsing a
+// form, and is the error returned whenever a field fails. It holds every
+// failure when the CollectAllErrors option is used, and otherwise the first
+// failure along with any missing fields. Errors are ordered by field name,
+// with the errors of a single field in the order they were found, so that the
+// order is the same for every parse of the same form.
+//
+// When more than one required field is missing, the message of Errors begins
+// with a summary naming every missing field, e.g. "missing: a, b, c".
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	var sb strings.Builder
+	sb.WriteString(ErrParseFailure.Error())
+	if len(e) == 0 {
+		return sb.String()
+	}
+	sb.WriteString(": ")
+	if missing := e.Missing(); len(missing) > 1 {
+		sb.WriteString("missing: ")
+		sb.WriteString(strings.Join(missing, ", "))
+		sb.WriteString("; ")
+	}
+	for i, fe := range e {
+		if i > 0 {
+			sb.WriteString("; ")
+		}
+		sb.WriteString(fe.Error())
+	}
+	return sb.String()
+}
+
+// Missing returns the names of the fields which failed because no value was
+// provided, in order of name.
+func (e Errors) Missing() []string {
+	var missing []string
+	for _, fe := range e {
+		if errors.Is(fe.Err, ErrNoValue) && !slices.Contains(missing, fe.Field) {
+			missing = append(missing, fe.Field)
+		}
+	}
+	return missing
+}
+
+func (e Errors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, fe := range e {
+		errs[i] = fe
+	}
+	return errs
+}
+
+// A CatalogEntry describes one of the errors which may be produced by this
+// package. The Code of an entry is stable across releases, and is suitable
+// for use as a key into translations of the error message.
+type CatalogEntry struct {
+	Code        string
+	Err         error
+	Description string
+}
+
+var catalog = []CatalogEntry{
+	{
+		Code:        "no_value",
+		Err:         ErrNoValue,
+		Description: "A required field was not submitted.",
+	},
+	{
+		Code:        "multiple_values",
+		Err:         ErrMulitpleValues,
+		Description: "A field accepting a single value was submitted more than once.",
+	},
+	{
+		Code:        "field_not_present",
+		Err:         ErrFieldNotPresent,
+		Description: "A requested field is not part of the schema.",
+	},
+	{
+		Code:        "parse_failure",
+		Err:         ErrParseFailure,
+		Description: "A submitted value could not be parsed into its destination type.",
+	},
+	{
+		Code:        "invalid_range",
+		Err:         ErrInvalidRange,
+		Description: "The lower bound of a submitted range exceeds its upper bound.",
+	},
+	{
+		Code:        "out_of_range",
+		Err:         ErrOutOfRange,
+		Description: "A submitted value lies outside the range of values accepted by the field.",
+	},
+	{
+		Code:        "unknown_unit",
+		Err:         ErrUnknownUnit,
+		Description: "A submitted value uses a unit which is not recognized.",
+	},
+	{
+		Code:        "card_number",
+		Err:         ErrCardNumber,
+		Description: "A submitted payment card number is malformed or fails its checksum.",
+	},
+	{
+		Code:        "unknown_code",
+		Err:         ErrUnknownCode,
+		Description: "A submitted country or currency code is not an assigned ISO code.",
+	},
+	{
+		Code:        "password_policy",
+		Err:         ErrPasswordPolicy,
+		Description: "A submitted password does not satisfy the password policy.",
+	},
+	{
+		Code:        "no_form",
+		Err:         ErrNoForm,
+		Description: "A parsed form was requested from a context which does not contain one.",
+	},
+	{
+		Code:        "not_registered",
+		Err:         ErrNotRegistered,
+		Description: "A form type was decoded without first registering its schema.",
+	},
+	{
+		Code:        "unexpected_field",
+		Err:         ErrUnexpectedField,
+		Description: "A field which is not part of the schema was submitted to a strict parse.",
+	},
+	{
+		Code:        "too_many_fields",
+		Err:         ErrTooManyFields,
+		Description: "The form contains more fields than the parse allows.",
+	},
+	{
+		Code:        "body_too_large",
+		Err:         ErrBodyTooLarge,
+		Description: "The request body exceeds the size limit of the parse.",
+	},
+	{
+		Code:        "invalid_schema",
+		Err:         ErrInvalidSchema,
+		Description: "A schema was found to contain a mistake when it was checked.",
+	},
+	{
+		Code:        "invalid_text",
+		Err:         ErrInvalidText,
+		Description: "A submitted value is not valid UTF-8 or contains control characters.",
+	},
+	{
+		Code:        "spam_suspected",
+		Err:         ErrSpamSuspected,
+		Description: "A honeypot field, which humans leave empty, contains a value.",
+	},
+	{
+		Code:        "invalid_token",
+		Err:         ErrInvalidToken,
+		Description: "A submitted CSRF or form token failed verification.",
+	},
+	{
+		Code:        "token_expired",
+		Err:         ErrTokenExpired,
+		Description: "A submitted form token has expired.",
+	},
+	{
+		Code:        "token_used",
+		Err:         ErrTokenUsed,
+		Description: "A submitted form token has already been used, e.g. by a duplicate submission.",
+	},
+	{
+		Code:        "unknown_name",
+		Err:         ErrUnknownName,
+		Description: "A submitted weekday or month is neither a number nor a recognized English name.",
+	},
+	{
+		Code:        "file_too_large",
+		Err:         ErrFileTooLarge,
+		Description: "An uploaded file exceeds the size limit of its field.",
+	},
+	{
+		Code:        "file_type",
+		Err:         ErrFileType,
+		Description: "The content of an uploaded file is not one of the types allowed by its field.",
+	},
+	{
+		Code:        "too_many_files",
+		Err:         ErrTooManyFiles,
+		Description: "More files were uploaded to a field than it allows.",
+	},
+	{
+		Code:        "invalid_choice",
+		Err:         ErrInvalidChoice,
+		Description: "A submitted value is not one of the choices offered by its field.",
+	},
+	{
+		Code:        "comparison",
+		Err:         ErrComparison,
+		Description: "The values of two fields do not satisfy a comparison between them, e.g. a start date after an end date.",
+	},
+	{
+		Code:        "length",
+		Err:         ErrLength,
+		Description: "A submitted value is shorter or longer than its field allows.",
+	},
+	{
+		Code:        "pattern",
+		Err:         ErrPattern,
+		Description: "A submitted value does not match the pattern required by its field.",
+	},
+	{
+		Code:        "unsupported_type",
+		Err:         ErrUnsupportedType,
+		Description: "A destination has a type for which no conversion is registered.",
+	},
+	{
+		Code:        "value_too_long",
+		Err:         ErrValueTooLong,
+		Description: "A submitted value is longer than the parse allows.",
+	},
+	{
+		Code:        "validation",
+		Err:         ErrValidation,
+		Description: "The parsed value was rejected by a StructValidator.",
+	},
+	{
+		Code:        "too_many_values",
+		Err:         ErrTooManyValues,
+		Description: "More values were submitted for a field than it allows.",
+	},
+}
+
+// ErrorCatalog returns an entry for every error which may be produced while
+// parsing a form, so that each may be enumerated, documented, or translated.
+func ErrorCatalog() []CatalogEntry {
+	return slices.Clone(catalog)
+}
+
+// ErrorCode returns the catalog code of err. Errors which do not match an
+// entry of the catalog (e.g. those from the strconv package) are reported as
+// a parse failure. If err is nil, the empty string is returned.
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	for _, entry := range catalog {
+		if errors.Is(err, entry.Err) {
+			return entry.Code
+		}
+	}
+	return "parse_failure"
+}
@@ -0,0 +1,41 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_ErrorCatalog_unique(t *testing.T) {
+	t.Parallel()
+
+	codes := make(map[string]bool)
+	for _, entry := range ErrorCatalog() {
+		must.NotEq(t, "", entry.Code)
+		must.NotEq(t, "", entry.Description)
+		must.NotNil(t, entry.Err)
+		must.False(t, codes[entry.Code], must.Sprint(entry.Code))
+		codes[entry.Code] = true
+	}
+}
+
+func Test_ErrorCode(t *testing.T) {
+	t.Parallel()
+
+	var (
+		one int
+		two int
+	)
+
+	err := ParseValues(url.Values{}, Schema{"one": Int(&one)})
+	must.Eq(t, "no_value", ErrorCode(err))
+
+	err = ParseValues(url.Values{"two": {"x"}}, Schema{"two": Int(&two)})
+	must.Eq(t, "parse_failure", ErrorCode(err))
+
+	must.Eq(t, "", ErrorCode(nil))
+}
@@ -0,0 +1,304 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// isMultipart reports whether the body of r is a multipart form.
+func isMultipart(r *http.Request) bool {
+	media, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && media == "multipart/form-data"
+}
+
+// sniffLen is the number of bytes considered by http.DetectContentType.
+const sniffLen = 512
+
+// A FileConstraint restricts the files accepted by a file Parser.
+type FileConstraint func(*fileRules)
+
+type fileRules struct {
+	maxSize  int64
+	types    []string
+	maxFiles int
+	maxTotal int64
+}
+
+// MaxSize limits an uploaded file to at most n bytes.
+func MaxSize(n int64) FileConstraint {
+	return func(r *fileRules) {
+		r.maxSize = n
+	}
+}
+
+// MaxFiles limits a field accepting multiple files to at most n files.
+func MaxFiles(n int) FileConstraint {
+	return func(r *fileRules) {
+		r.maxFiles = n
+	}
+}
+
+// MaxTotalSize limits the combined size of the files uploaded to a field
+// accepting multiple files to at most n bytes.
+func MaxTotalSize(n int64) FileConstraint {
+	return func(r *fileRules) {
+		r.maxTotal = n
+	}
+}
+
+// AllowTypes limits an uploaded file to the given media types, such as
+// "image/png". The type of the file is detected from its content, rather than
+// trusting the Content-Type declared by the client.
+func AllowTypes(types ...string) FileConstraint {
+	return func(r *fileRules) {
+		r.types = append(r.types, types...)
+	}
+}
+
+func newFileRules(constraints []FileConstraint) fileRules {
+	var r fileRules
+	for _, constraint := range constraints {
+		constraint(&r)
+	}
+	return r
+}
+
+func (r *fileRules) describe() []string {
+	var constraints []string
+	if r.maxSize > 0 {
+		constraints = append(constraints, fmt.Sprintf("at most %d bytes", r.maxSize))
+	}
+	if len(r.types) > 0 {
+		constraints = append(constraints, "type is one of "+strings.Join(r.types, ", "))
+	}
+	if r.maxFiles > 0 {
+		constraints = append(constraints, fmt.Sprintf("at most %d files", r.maxFiles))
+	}
+	if r.maxTotal > 0 {
+		constraints = append(constraints, fmt.Sprintf("at most %d bytes in total", r.maxTotal))
+	}
+	return constraints
+}
+
+// checkAll reports whether files satisfy the rules, both individually and
+// as a whole.
+func (r *fileRules) checkAll(files []*multipart.FileHeader) error {
+	if r.maxFiles > 0 && len(files) > r.maxFiles {
+		return fmt.Errorf("%w: limit is %d", ErrTooManyFiles, r.maxFiles)
+	}
+
+	var total int64
+	for _, fh := range files {
+		total += fh.Size
+	}
+	if r.maxTotal > 0 && total > r.maxTotal {
+		return fmt.Errorf("%w: total limit is %d bytes", ErrFileTooLarge, r.maxTotal)
+	}
+
+	for _, fh := range files {
+		if err := r.check(fh); err != nil {
+			return fmt.Errorf("%s: %w", fh.Filename, err)
+		}
+	}
+	return nil
+}
+
+// check reports whether fh satisfies the rules.
+func (r *fileRules) check(fh *multipart.FileHeader) error {
+	if r.maxSize > 0 && fh.Size > r.maxSize {
+		return fmt.Errorf("%w: limit is %d bytes", ErrFileTooLarge, r.maxSize)
+	}
+	if len(r.types) == 0 {
+		return nil
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return err
+	}
+
+	media, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	if !slices.Contains(r.types, media) {
+		return fmt.Errorf("%w: detected %s", ErrFileType, media)
+	}
+	return nil
+}
+
+type fileParser struct {
+	rules       fileRules
+	set         func(*multipart.FileHeader) error
+	destination any
+}
+
+func (p *fileParser) describe() field {
+	return field{kind: "file", required: true, constraints: p.rules.describe()}
+}
+
+func (p *fileParser) destinations() []any {
+	return []any{p.destination}
+}
+
+// Parse implements Parser. Files are only available when parsing a multipart
+// http.Request, so Parse always fails with ErrNoValue.
+func (p *fileParser) Parse([]string) error {
+	return ErrNoValue
+}
+
+func (p *fileParser) parseForm(_ context.Context, name string, _ url.Values, o *options) error {
+	files := o.files[name]
+	switch {
+	case len(files) > 1:
+		return ErrMulitpleValues
+	case len(files) == 0:
+		return ErrNoValue
+	}
+
+	if err := p.rules.check(files[0]); err != nil {
+		return err
+	}
+	if o.dryRun {
+		// the file is not saved or copied by Schema.Validate
+		return nil
+	}
+
+	return p.set(files[0])
+}
+
+func (p *fileParser) claims(name, key string) bool {
+	return key == name
+}
+
+// File is used to extract an uploaded file of a multipart form into a Go
+// multipart.FileHeader. If the file is missing, or does not satisfy the given
+// constraints, then an error is returned during parsing. Files are only
+// available to Parse, and not to ParseValues.
+func File(fh **multipart.FileHeader, constraints ...FileConstraint) Parser {
+	return &fileParser{
+		rules: newFileRules(constraints),
+		set: func(f *multipart.FileHeader) error {
+			*fh = f
+			return nil
+		},
+		destination: fh,
+	}
+}
+
+// streamRequest reads the multipart body of r, giving each file part to fn
+// and collecting the remaining values into the form of r. Values are limited
+// to defaultMaxMemory bytes in total, since unlike files they are buffered.
+func streamRequest(r *http.Request, fn func(string, io.Reader) error) error {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return err
+	}
+
+	form, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		return err
+	}
+	post := make(url.Values)
+
+	remaining := int64(defaultMaxMemory)
+	for {
+		part, err := mr.NextPart()
+		switch {
+		case err == io.EOF:
+			for key, values := range post {
+				form[key] = append(values, form[key]...)
+			}
+			r.Form, r.PostForm = form, post
+			return nil
+		case err != nil:
+			return err
+		}
+
+		name := part.FormName()
+		if name == "" {
+			continue
+		}
+
+		if part.FileName() != "" {
+			if err := fn(name, part); err != nil {
+				return Errors{{Field: name, Err: err}}
+			}
+			continue
+		}
+
+		value, err := io.ReadAll(io.LimitReader(part, remaining+1))
+		if err != nil {
+			return err
+		}
+		remaining -= int64(len(value))
+		if remaining < 0 {
+			return fmt.Errorf("%w: values exceed %d bytes", ErrBodyTooLarge, defaultMaxMemory)
+		}
+		post[name] = append(post[name], string(value))
+	}
+}
+
+type filesParser struct {
+	rules       fileRules
+	destination *[]*multipart.FileHeader
+}
+
+func (p *filesParser) describe() field {
+	return field{kind: "files", required: true, multiple: true, constraints: p.rules.describe()}
+}
+
+func (p *filesParser) destinations() []any {
+	return []any{p.destination}
+}
+
+// Parse implements Parser. Files are only available when parsing a multipart
+// http.Request, so Parse always fails with ErrNoValue.
+func (p *filesParser) Parse([]string) error {
+	return ErrNoValue
+}
+
+func (p *filesParser) parseForm(_ context.Context, name string, _ url.Values, o *options) error {
+	files := o.files[name]
+	if len(files) == 0 {
+		return ErrNoValue
+	}
+
+	if err := p.rules.checkAll(files); err != nil {
+		return err
+	}
+
+	*p.destination = files
+	return nil
+}
+
+func (p *filesParser) claims(name, key string) bool {
+	return key == name
+}
+
+// Files is used to extract every file uploaded to a field of a multipart form
+// into a slice of Go multipart.FileHeader, as submitted by an input with the
+// multiple attribute. Each file is checked against the given constraints,
+// along with MaxFiles and MaxTotalSize which apply to the files as a whole. If
+// no file was uploaded, or the constraints are not satisfied, then an error is
+// returned during parsing.
+func Files(fhs *[]*multipart.FileHeader, constraints ...FileConstraint) Parser {
+	return &filesParser{
+		rules:       newFileRules(constraints),
+		destination: fhs,
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+type upload struct {
+	field    string
+	filename string
+	content  []byte
+}
+
+func postMultipart(t *testing.T, values map[string]string, uploads ...upload) *http.Request {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for name, value := range values {
+		must.NoError(t, w.WriteField(name, value))
+	}
+	for _, u := range uploads {
+		part, err := w.CreateFormFile(u.field, u.filename)
+		must.NoError(t, err)
+		_, err = part.Write(u.content)
+		must.NoError(t, err)
+	}
+	must.NoError(t, w.Close())
+
+	request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/", &body)
+	request.Header.Set("Content-Type", w.FormDataContentType())
+	return request
+}
+
+func Test_Parse_File(t *testing.T) {
+	t.Parallel()
+
+	r := postMultipart(t, map[string]string{"caption": "a cat"},
+		upload{field: "photo", filename: "cat.png", content: pngHeader},
+	)
+
+	var (
+		caption string
+		photo   *multipart.FileHeader
+	)
+	err := Parse(r, Schema{
+		"caption": String(&caption),
+		"photo":   File(&photo, MaxSize(1<<10), AllowTypes("image/png", "image/jpeg")),
+	}, Strict())
+	must.NoError(t, err)
+	must.Eq(t, "a cat", caption)
+	must.Eq(t, "cat.png", photo.Filename)
+	must.Eq(t, int64(len(pngHeader)), photo.Size)
+}
+
+func Test_Parse_File_sniffed(t *testing.T) {
+	t.Parallel()
+
+	// the file name claims an image, but the content is a script
+	r := postMultipart(t, nil,
+		upload{field: "photo", filename: "cat.png", content: []byte("<script>alert(1)</script>")},
+	)
+
+	var photo *multipart.FileHeader
+	err := Parse(r, Schema{
+		"photo": File(&photo, AllowTypes("image/png")),
+	})
+	must.ErrorIs(t, err, ErrFileType)
+	must.Nil(t, photo)
+}
+
+func Test_Parse_File_too_large(t *testing.T) {
+	t.Parallel()
+
+	r := postMultipart(t, nil,
+		upload{field: "photo", filename: "cat.png", content: bytes.Repeat([]byte("x"), 100)},
+	)
+
+	var photo *multipart.FileHeader
+	err := Parse(r, Schema{
+		"photo": File(&photo, MaxSize(99)),
+	})
+	must.ErrorIs(t, err, ErrFileTooLarge)
+}
+
+func Test_Parse_File_missing(t *testing.T) {
+	t.Parallel()
+
+	var photo *multipart.FileHeader
+	err := Parse(postMultipart(t, map[string]string{"caption": "a cat"}), Schema{
+		"photo": File(&photo),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+
+	err = Parse(postMultipart(t, nil,
+		upload{field: "photo", filename: "a.png", content: pngHeader},
+		upload{field: "photo", filename: "b.png", content: pngHeader},
+	), Schema{
+		"photo": File(&photo),
+	})
+	must.ErrorIs(t, err, ErrMulitpleValues)
+}
+
+func Test_Parse_StreamFiles(t *testing.T) {
+	t.Parallel()
+
+	r := postMultipart(t, map[string]string{"caption": "two cats"},
+		upload{field: "photo", filename: "a.png", content: pngHeader},
+		upload{field: "photo", filename: "b.png", content: []byte("second")},
+	)
+
+	var (
+		caption string
+		names   []string
+		sizes   []int
+	)
+	err := Parse(r, Schema{
+		"caption": String(&caption),
+	}, Strict(), StreamFiles(func(name string, part io.Reader) error {
+		n, err := io.Copy(io.Discard, part)
+		names = append(names, name)
+		sizes = append(sizes, int(n))
+		return err
+	}))
+	must.NoError(t, err)
+	must.Eq(t, "two cats", caption)
+	must.Eq(t, []string{"photo", "photo"}, names)
+	must.Eq(t, []int{len(pngHeader), 6}, sizes)
+}
+
+func Test_Parse_StreamFiles_error(t *testing.T) {
+	t.Parallel()
+
+	r := postMultipart(t, nil,
+		upload{field: "photo", filename: "a.png", content: pngHeader},
+	)
+
+	errFull := errors.New("disk is full")
+	err := Parse(r, Schema{}, StreamFiles(func(string, io.Reader) error {
+		return errFull
+	}))
+	must.ErrorIs(t, err, errFull)
+	must.StrContains(t, err.Error(), "photo")
+}
+
+func Test_Parse_Files(t *testing.T) {
+	t.Parallel()
+
+	r := postMultipart(t, nil,
+		upload{field: "gallery", filename: "a.png", content: pngHeader},
+		upload{field: "gallery", filename: "b.png", content: pngHeader},
+		upload{field: "gallery", filename: "c.png", content: pngHeader},
+	)
+
+	var gallery []*multipart.FileHeader
+	err := Parse(r, Schema{
+		"gallery": Files(&gallery, MaxFiles(3), MaxTotalSize(1<<10), AllowTypes("image/png")),
+	})
+	must.NoError(t, err)
+	must.SliceLen(t, 3, gallery)
+	must.Eq(t, "c.png", gallery[2].Filename)
+}
+
+func Test_Parse_Files_limits(t *testing.T) {
+	t.Parallel()
+
+	uploads := []upload{
+		{field: "gallery", filename: "a.png", content: pngHeader},
+		{field: "gallery", filename: "b.txt", content: []byte("not an image")},
+	}
+
+	var gallery []*multipart.FileHeader
+	err := Parse(postMultipart(t, nil, uploads...), Schema{
+		"gallery": Files(&gallery, MaxFiles(1)),
+	})
+	must.ErrorIs(t, err, ErrTooManyFiles)
+
+	err = Parse(postMultipart(t, nil, uploads...), Schema{
+		"gallery": Files(&gallery, MaxTotalSize(int64(len(pngHeader)))),
+	})
+	must.ErrorIs(t, err, ErrFileTooLarge)
+
+	err = Parse(postMultipart(t, nil, uploads...), Schema{
+		"gallery": Files(&gallery, AllowTypes("image/png")),
+	})
+	must.ErrorIs(t, err, ErrFileType)
+	must.StrContains(t, err.Error(), "b.txt")
+
+	err = Parse(postMultipart(t, nil), Schema{
+		"gallery": Files(&gallery),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+	must.SliceEmpty(t, gallery)
+}
@@ -0,0 +1,40 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"flag"
+)
+
+type valueParser struct {
+	destination flag.Value
+}
+
+func (p *valueParser) describe() field {
+	return field{kind: "value", required: true}
+}
+
+func (p *valueParser) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *valueParser) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0:
+		return ErrNoValue
+	}
+	return p.destination.Set(values[0])
+}
+
+// Value is used to extract a form data value into any Go type implementing
+// flag.Value, by passing the value to its Set method. This allows the same
+// type to be used for command line flags and form fields. If the value is
+// missing, or is rejected by Set, then an error is returned during parsing.
+func Value(v flag.Value) Parser {
+	return &valueParser{
+		destination: v,
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+type level string
+
+func (l *level) String() string {
+	return string(*l)
+}
+
+func (l *level) Set(s string) error {
+	switch s {
+	case "debug", "info", "error":
+		*l = level(s)
+		return nil
+	default:
+		return errors.New("unknown level")
+	}
+}
+
+func Test_Parse_Value(t *testing.T) {
+	t.Parallel()
+
+	var l level
+	err := ParseValues(url.Values{"level": {"info"}}, Schema{
+		"level": Value(&l),
+	})
+	must.NoError(t, err)
+	must.Eq(t, "info", l.String())
+
+	err = ParseValues(url.Values{"level": {"trace"}}, Schema{
+		"level": Value(&l),
+	})
+	must.Error(t, err)
+
+	err = ParseValues(url.Values{}, Schema{
+		"level": Value(&l),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+
+	err = ParseValues(url.Values{"level": {"info", "debug"}}, Schema{
+		"level": Value(&l),
+	})
+	must.ErrorIs(t, err, ErrMulitpleValues)
+}
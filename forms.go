@@ -6,6 +6,7 @@
 package forms
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -16,48 +17,102 @@ import (
 	"github.com/shoenig/lang"
 )
 
-var (
-	ErrNoValue         = errors.New("expected value to exist")
-	ErrMulitpleValues  = errors.New("expected only one value to exist")
-	ErrFieldNotPresent = errors.New("requested field does not exist")
-	ErrParseFailure    = errors.New("could not parse value")
-)
-
-// Parse uses the given Schema to parse the HTTP form values in the given HTTP
-// Request. If the values of the form do not match the schema, or required values
-// are missing, a panic is triggered.
-func MustParse(r *http.Request, schema Schema) {
-	if err := r.ParseForm(); err != nil {
-		panic("forms: " + err.Error())
-	}
-
-	if err := ParseValues(r.Form, schema); err != nil {
+// MustParse uses the given Schema to parse the HTTP form values in the given
+// HTTP Request. If the values of the form do not match the schema, or required
+// values are missing, a panic is triggered.
+func MustParse(r *http.Request, schema Schema, opts ...Option) {
+	if err := Parse(r, schema, opts...); err != nil {
 		panic("forms: " + err.Error())
 	}
 }
 
 // Parse uses the given Schema to parse the HTTP form values in the given HTTP
 // Request. If the values of the form do not match the schema, or required values
-// are missing, an error is returned.
-func Parse(r *http.Request, schema Schema) error {
-	if err := r.ParseForm(); err != nil {
+// are missing, an error is returned. Even when the CollectAllErrors option is
+// not used, every missing field is named in the error, along with the first
+// field which failed for any other reason. The context of the request is given
+// to any ParserContext implementations in the schema.
+func Parse(r *http.Request, schema Schema, opts ...Option) error {
+	o := newOptions(opts)
+
+	if err := parseRequest(r, o); err != nil {
 		return err
 	}
 
-	return ParseValues(r.Form, schema)
+	return parse(r.Context(), r.Form, schema, o)
+}
+
+// defaultMaxMemory is the number of bytes of a multipart form kept in memory,
+// the remainder being stored in temporary files. It matches the default used
+// by http.Request.FormFile.
+const defaultMaxMemory = 32 << 20
+
+// parseRequest populates the form values of r, honoring the request body size
+// limit of o. The files of a multipart form are made available to o.
+func parseRequest(r *http.Request, o *options) error {
+	if o.maxBytes > 0 && r.Body != nil {
+		r.Body = http.MaxBytesReader(nil, r.Body, o.maxBytes)
+	}
+
+	var err error
+	switch {
+	case o.stream != nil && isMultipart(r):
+		err = streamRequest(r, o.stream)
+	case isMultipart(r):
+		err = r.ParseMultipartForm(defaultMaxMemory)
+		if err == nil {
+			o.files = r.MultipartForm.File
+		}
+	default:
+		err = r.ParseForm()
+	}
+
+	if mbe := (*http.MaxBytesError)(nil); errors.As(err, &mbe) {
+		return fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, mbe.Limit)
+	}
+	return err
 }
 
 // ParseValues uses the given Schema to parse the values in the given url.Values.
 // If the values do not match the schema, or required values are missing, an
-// error is returned.
-func ParseValues(data url.Values, schema Schema) error {
-	for name, parser := range schema {
-		values := data[name]
-		if err := parser.Parse(values); err != nil {
-			return fmt.Errorf("%s: %w", ErrParseFailure.Error(), err)
+// error is returned. As with Parse, every missing field is named in the error.
+func ParseValues(data url.Values, schema Schema, opts ...Option) error {
+	return ParseValuesContext(context.Background(), data, schema, opts...)
+}
+
+// ParseValuesContext is like ParseValues, giving ctx to any ParserContext
+// implementations in the schema. If ctx is cancelled or its deadline passes,
+// parsing stops and the error of the context is returned.
+func ParseValuesContext(ctx context.Context, data url.Values, schema Schema, opts ...Option) error {
+	return parse(ctx, data, schema, newOptions(opts))
+}
+
+// ParseFields is like ParseValues, but parses only the named fields of schema.
+// This allows a large shared schema to be reused for each step of a multi-step
+// form. Each of fields must be present in schema, otherwise an error wrapping
+// ErrFieldNotPresent is returned before anything is parsed.
+func ParseFields(data url.Values, schema Schema, fields ...string) error {
+	subset := make(Schema, len(fields))
+	for _, name := range fields {
+		parser, exists := schema[name]
+		if !exists {
+			return fmt.Errorf("%w: %q", ErrFieldNotPresent, name)
 		}
+		subset[name] = parser
 	}
-	return nil
+	return parse(context.Background(), data, subset, new(options))
+}
+
+// parse is the implementation of every parsing entry point for a Schema.
+func parse(ctx context.Context, data url.Values, schema Schema, o *options) error {
+	return compile(schema).parse(ctx, data, o)
+}
+
+// parseField applies parser to the values of the named field. Parsers which
+// need to see the complete form are given all of data.
+func parseField(ctx context.Context, name string, parser Parser, data url.Values, o *options) error {
+	f := compileField(name, parser)
+	return f.parse(ctx, data, o)
 }
 
 // A Schema describes how a set of url.Values should be parsed.
@@ -74,6 +129,26 @@ type Parser interface {
 	Parse([]string) error
 }
 
+// A ParserContext is a Parser which is given the context of the request being
+// parsed, e.g. so that a validator which makes use of a database or remote
+// service can respect cancellation and deadlines. When a ParserContext is
+// part of a Schema, ParseContext is called instead of Parse.
+type ParserContext interface {
+	Parser
+	ParseContext(ctx context.Context, values []string) error
+}
+
+// A formParser is a Parser that reads from every value of the submitted form,
+// rather than only the values submitted under its own field name.
+type formParser interface {
+	Parser
+	parseForm(ctx context.Context, name string, data url.Values, o *options) error
+
+	// claims reports whether the form value named key is read by the
+	// parser when it is used for the schema field of the given name.
+	claims(name, key string) bool
+}
+
 // StringType represents any type compatible with the Go string built-in type,
 // to be used as a destination for writing the value of an environment variable.
 type StringType interface {
@@ -82,9 +157,18 @@ type StringType interface {
 
 type stringParser[T StringType] struct {
 	required    bool
+	alt         T
 	destination *T
 }
 
+func (p *stringParser[T]) describe() field {
+	return field{kind: "string", required: p.required, alt: p.alt}
+}
+
+func (p *stringParser[T]) destinations() []any {
+	return []any{p.destination}
+}
+
 func (p *stringParser[T]) Parse(values []string) error {
 	switch {
 	case len(values) > 1:
@@ -114,15 +198,25 @@ func StringOr[T StringType](s *T, alt T) Parser {
 	*s = alt
 	return &stringParser[T]{
 		required:    false,
+		alt:         alt,
 		destination: s,
 	}
 }
 
 type stringsParser[T StringType] struct {
 	required    bool
+	alt         []T
 	destination *[]T
 }
 
+func (p *stringsParser[T]) describe() field {
+	return field{kind: "strings", required: p.required, multiple: true, alt: p.alt}
+}
+
+func (p *stringsParser[T]) destinations() []any {
+	return []any{p.destination}
+}
+
 func (p *stringsParser[T]) Parse(values []string) error {
 	switch {
 	case len(values) == 0 && p.required:
@@ -152,6 +246,7 @@ func StringsOr[T StringType](s *[]T, alt []T) Parser {
 	*s = alt
 	return &stringsParser[T]{
 		required:    false,
+		alt:         alt,
 		destination: s,
 	}
 }
@@ -161,13 +256,51 @@ func StringsOr[T StringType](s *[]T, alt []T) Parser {
 func Secret(s **conceal.Text) Parser {
 	return &secretParser{
 		required:    true,
+		sink:        concealSink{destination: s},
 		destination: s,
 	}
 }
 
+// A SecretSink receives the value of a secret form field, as extracted by
+// SecretTo. Implementations wrap the value in a type which prevents it from
+// being exposed, e.g. by logging.
+type SecretSink interface {
+	SetSecret(string)
+}
+
+// SecretTo is used to extract a form data value into sink, for applications
+// which wrap secrets with a library other than conceal (e.g. memguard). If
+// the value is missing then an error is returned during parsing.
+func SecretTo(sink SecretSink) Parser {
+	return &secretParser{
+		required:    true,
+		sink:        sink,
+		destination: sink,
+	}
+}
+
+// concealSink is the SecretSink of Secret, which stores the value in a Go
+// conceal.Text.
+type concealSink struct {
+	destination **conceal.Text
+}
+
+func (s concealSink) SetSecret(value string) {
+	*s.destination = conceal.New(value)
+}
+
 type secretParser struct {
 	required    bool
-	destination **conceal.Text
+	sink        SecretSink
+	destination any
+}
+
+func (p *secretParser) describe() field {
+	return field{kind: "secret", required: p.required, sensitive: true}
+}
+
+func (p *secretParser) destinations() []any {
+	return []any{p.destination}
 }
 
 func (p *secretParser) Parse(values []string) error {
@@ -179,8 +312,17 @@ func (p *secretParser) Parse(values []string) error {
 	case len(values) == 0:
 		return nil
 	default:
-		text := conceal.New(values[0])
-		*p.destination = text
+		p.sink.SetSecret(values[0])
+	}
+	return nil
+}
+
+func (p *secretParser) dryRun(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
 	}
 	return nil
 }
@@ -194,9 +336,18 @@ type IntType interface {
 
 type intParser[T IntType] struct {
 	required    bool
+	alt         T
 	destination *T
 }
 
+func (p *intParser[T]) describe() field {
+	return field{kind: "int", required: p.required, alt: p.alt}
+}
+
+func (p *intParser[T]) destinations() []any {
+	return []any{p.destination}
+}
+
 func (p *intParser[T]) Parse(values []string) error {
 	switch {
 	case len(values) > 1:
@@ -231,15 +382,25 @@ func IntOr[T IntType](i *T, alt T) Parser {
 	*i = alt
 	return &intParser[T]{
 		required:    false,
+		alt:         alt,
 		destination: i,
 	}
 }
 
 type floatParser struct {
 	required    bool
+	alt         float64
 	destination *float64
 }
 
+func (p *floatParser) describe() field {
+	return field{kind: "float", required: p.required, alt: p.alt}
+}
+
+func (p *floatParser) destinations() []any {
+	return []any{p.destination}
+}
+
 // Float is used to extract a form data value into a Go float64. If the value is
 // not a float or is missing then an error is returned during parsing.
 func Float(f *float64) Parser {
@@ -255,6 +416,7 @@ func FloatOr(f *float64, alt float64) Parser {
 	*f = alt
 	return &floatParser{
 		required:    false,
+		alt:         alt,
 		destination: f,
 	}
 }
@@ -280,9 +442,18 @@ func (p *floatParser) Parse(values []string) error {
 
 type boolParser struct {
 	required    bool
+	alt         bool
 	destination *bool
 }
 
+func (p *boolParser) describe() field {
+	return field{kind: "bool", required: p.required, alt: p.alt}
+}
+
+func (p *boolParser) destinations() []any {
+	return []any{p.destination}
+}
+
 // Bool is used to extract a form data value into a Go bool. If the value is not
 // a bool or is missing than an error is returned during parsing.
 func Bool(b *bool) Parser {
@@ -298,6 +469,7 @@ func BoolOr(b *bool, alt bool) Parser {
 	*b = alt
 	return &boolParser{
 		required:    false,
+		alt:         alt,
 		destination: b,
 	}
 }
@@ -5,6 +5,7 @@ package forms
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/url"
 	"testing"
@@ -110,6 +111,40 @@ func Test_Parse_singles(t *testing.T) {
 	must.Eq(t, "xyz", five.Unveil())
 }
 
+// vault is a SecretSink which keeps the secret out of its string form.
+type vault struct {
+	secret []byte
+}
+
+func (v *vault) SetSecret(value string) {
+	v.secret = []byte(value)
+}
+
+func (v *vault) String() string {
+	return "vault(redacted)"
+}
+
+func Test_Parse_SecretTo(t *testing.T) {
+	t.Parallel()
+
+	var key vault
+	err := ParseValues(url.Values{"key": {"s3cret"}}, Schema{
+		"key": SecretTo(&key),
+	})
+	must.NoError(t, err)
+	must.Eq(t, []byte("s3cret"), key.secret)
+
+	err = ParseValues(url.Values{}, Schema{"key": SecretTo(&key)})
+	must.ErrorIs(t, err, ErrNoValue)
+
+	fields := Schema{"key": SecretTo(&key)}.Fields()
+	must.True(t, fields[0].Sensitive)
+
+	var missing *vault
+	err = Schema{"key": SecretTo(missing)}.Check()
+	must.ErrorIs(t, err, ErrInvalidSchema)
+}
+
 func Test_Parse_singles_Or(t *testing.T) {
 	t.Parallel()
 
@@ -489,3 +524,91 @@ func Test_Parse_StringType_StringsOr_missing(t *testing.T) {
 	must.NoError(t, err)
 	must.Eq(t, []string{"janitor", "cashier"}, jobs)
 }
+
+var errTaken = errors.New("username is taken")
+
+type uniqueParser struct {
+	taken string
+}
+
+func (p *uniqueParser) Parse(values []string) error {
+	return p.ParseContext(context.Background(), values)
+}
+
+func (p *uniqueParser) ParseContext(ctx context.Context, values []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(values) == 1 && values[0] == p.taken {
+		return errTaken
+	}
+	return nil
+}
+
+func Test_ParseValuesContext(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"username": []string{"bob"},
+	}
+
+	err := ParseValuesContext(t.Context(), data, Schema{
+		"username": &uniqueParser{taken: "alice"},
+	})
+	must.NoError(t, err)
+
+	err = ParseValuesContext(t.Context(), data, Schema{
+		"username": &uniqueParser{taken: "bob"},
+	})
+	must.ErrorIs(t, err, errTaken)
+}
+
+func Test_ParseValuesContext_cancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	var one string
+	err := ParseValuesContext(ctx, url.Values{"one": {"1"}}, Schema{
+		"one": String(&one),
+	})
+	must.ErrorIs(t, err, context.Canceled)
+	must.Eq(t, "", one)
+}
+
+func Test_ParseFields(t *testing.T) {
+	t.Parallel()
+
+	var (
+		name  string
+		email string
+		age   int
+	)
+
+	schema := Schema{
+		"name":  String(&name),
+		"email": String(&email),
+		"age":   Int(&age),
+	}
+
+	err := ParseFields(url.Values{"name": {"bob"}, "email": {"bob@example.com"}}, schema, "name", "email")
+	must.NoError(t, err)
+	must.Eq(t, "bob", name)
+	must.Eq(t, "bob@example.com", email)
+	must.Eq(t, 0, age)
+
+	err = ParseFields(url.Values{"name": {"bob"}}, schema, "name", "email")
+	must.ErrorIs(t, err, ErrNoValue)
+}
+
+func Test_ParseFields_not_present(t *testing.T) {
+	t.Parallel()
+
+	var name string
+	err := ParseFields(url.Values{"name": {"bob"}}, Schema{
+		"name": String(&name),
+	}, "name", "phone")
+	must.ErrorIs(t, err, ErrFieldNotPresent)
+	must.Eq(t, "", name)
+}
@@ -0,0 +1,128 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type latLongParser struct {
+	required            bool
+	altLat, altLong     float64
+	latitude, longitude *float64
+}
+
+func (p *latLongParser) describe() field {
+	f := field{
+		kind:     "coordinates",
+		required: p.required,
+		constraints: []string{
+			"submitted as name (lat,long) or name_lat and name_long",
+			"-90 <= lat <= 90",
+			"-180 <= long <= 180",
+		},
+	}
+	if !p.required {
+		f.alt = fmt.Sprintf("%g,%g", p.altLat, p.altLong)
+	}
+	return f
+}
+
+func (p *latLongParser) destinations() []any {
+	return []any{p.latitude, p.longitude}
+}
+
+// Parse implements Parser, extracting coordinates written in the form
+// "lat,long".
+func (p *latLongParser) Parse(values []string) error {
+	return p.parseForm(context.Background(), "", url.Values{"": values}, new(options))
+}
+
+func (p *latLongParser) parseForm(_ context.Context, name string, data url.Values, _ *options) error {
+	combined := data[name]
+	switch {
+	case len(combined) > 1:
+		return ErrMulitpleValues
+	case len(combined) == 1:
+		return p.combined(combined[0])
+	}
+
+	lats, longs := data[name+"_lat"], data[name+"_long"]
+	switch {
+	case len(lats) > 1 || len(longs) > 1:
+		return ErrMulitpleValues
+	case len(lats) == 0 && len(longs) == 0 && !p.required:
+		return nil
+	case len(lats) == 0 || len(longs) == 0:
+		// a lone coordinate does not identify a location
+		return ErrNoValue
+	}
+
+	return p.parse(lats[0], longs[0])
+}
+
+func (p *latLongParser) claims(name, key string) bool {
+	return key == name || key == name+"_lat" || key == name+"_long"
+}
+
+func (p *latLongParser) combined(value string) error {
+	lat, long, found := strings.Cut(value, ",")
+	if !found {
+		// a value was submitted, it is just not a pair of coordinates
+		return fmt.Errorf("%w: expected coordinates as lat,long", strconv.ErrSyntax)
+	}
+	return p.parse(lat, long)
+}
+
+func (p *latLongParser) parse(latValue, longValue string) error {
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latValue), 64)
+	if err != nil {
+		return err
+	}
+
+	long, err := strconv.ParseFloat(strings.TrimSpace(longValue), 64)
+	if err != nil {
+		return err
+	}
+
+	// written as negations so that NaN is rejected
+	if !(lat >= -90 && lat <= 90) || !(long >= -180 && long <= 180) {
+		return ErrOutOfRange
+	}
+
+	*p.latitude, *p.longitude = lat, long
+	return nil
+}
+
+// LatLong is used to extract a geographic coordinate into a pair of Go
+// float64 values. The coordinate may be submitted as a single form value like
+// "51.5,-0.12", or as a pair of form values named with "_lat" and "_long"
+// suffixes of the schema field name. If the latitude is not between -90 and
+// 90, the longitude is not between -180 and 180, or either is missing, then an
+// error is returned during parsing.
+func LatLong(lat, long *float64) Parser {
+	return &latLongParser{
+		required:  true,
+		latitude:  lat,
+		longitude: long,
+	}
+}
+
+// LatLongOr is used to extract a geographic coordinate into a pair of Go
+// float64 values. If the coordinate is missing, then the alt values are used
+// instead.
+func LatLongOr(lat, long *float64, altLat, altLong float64) Parser {
+	*lat, *long = altLat, altLong
+	return &latLongParser{
+		required:  false,
+		altLat:    altLat,
+		altLong:   altLong,
+		latitude:  lat,
+		longitude: long,
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_LatLong_combined(t *testing.T) {
+	t.Parallel()
+
+	var lat, long float64
+	err := ParseValues(url.Values{"location": {"51.5, -0.12"}}, Schema{
+		"location": LatLong(&lat, &long),
+	}, Strict())
+	must.NoError(t, err)
+	must.Eq(t, 51.5, lat)
+	must.Eq(t, -0.12, long)
+}
+
+func Test_Parse_LatLong_paired(t *testing.T) {
+	t.Parallel()
+
+	var lat, long float64
+	err := ParseValues(url.Values{"location_lat": {"-33.86"}, "location_long": {"151.2"}}, Schema{
+		"location": LatLong(&lat, &long),
+	}, Strict())
+	must.NoError(t, err)
+	must.Eq(t, -33.86, lat)
+	must.Eq(t, 151.2, long)
+}
+
+func Test_Parse_LatLong_invalid(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		data url.Values
+		exp  error
+	}{
+		"lat too big":    {url.Values{"loc": {"91,0"}}, ErrOutOfRange},
+		"long too small": {url.Values{"loc": {"0,-180.5"}}, ErrOutOfRange},
+		"nan":            {url.Values{"loc": {"NaN,0"}}, ErrOutOfRange},
+		"no separator":   {url.Values{"loc": {"51.5"}}, strconv.ErrSyntax},
+		"lone lat":       {url.Values{"loc_lat": {"51.5"}}, ErrNoValue},
+		"missing":        {url.Values{}, ErrNoValue},
+		"multiple":       {url.Values{"loc": {"1,1", "2,2"}}, ErrMulitpleValues},
+	}
+	for name, tc := range cases {
+		var lat, long float64
+		err := ParseValues(tc.data, Schema{
+			"loc": LatLong(&lat, &long),
+		})
+		must.ErrorIs(t, err, tc.exp, must.Sprint(name))
+	}
+}
+
+func Test_Parse_LatLongOr(t *testing.T) {
+	t.Parallel()
+
+	var lat, long float64
+	err := ParseValues(url.Values{}, Schema{
+		"loc": LatLongOr(&lat, &long, 40.7, -74),
+	})
+	must.NoError(t, err)
+	must.Eq(t, 40.7, lat)
+	must.Eq(t, -74.0, long)
+
+	err = ParseValues(url.Values{"loc_long": {"10"}}, Schema{
+		"loc": LatLongOr(&lat, &long, 40.7, -74),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/url"
+	"strings"
+)
+
+type groupParser struct {
+	schema   Schema
+	compiled *CompiledSchema
+}
+
+func (p *groupParser) describe() field {
+	f := field{
+		kind:        "group",
+		required:    true,
+		constraints: []string{"submitted as name[field]"},
+	}
+	for _, parser := range p.schema {
+		f.sensitive = f.sensitive || compileField("", parser).sensitive
+	}
+	return f
+}
+
+func (p *groupParser) destinations() []any {
+	var all []any
+	for _, parser := range p.schema {
+		all = append(all, destinations(parser)...)
+	}
+	return all
+}
+
+// Parse implements Parser, parsing the group as if none of its fields were
+// submitted.
+func (p *groupParser) Parse([]string) error {
+	return p.parseForm(context.Background(), "", url.Values{}, new(options))
+}
+
+func (p *groupParser) parseForm(ctx context.Context, name string, data url.Values, o *options) error {
+	// rewrite name[field]... as field... so that the fields of the group,
+	// including nested groups, find their values under their own names
+	view := make(url.Values)
+	for key, values := range data {
+		if inner, ok := innerName(name, key); ok {
+			view[inner] = append(view[inner], values...)
+		}
+	}
+
+	// the form as a whole has already been checked and trimmed, and the
+	// fields of the group are reported by their complete names
+	inner := options{
+		strict:   o.strict,
+		collect:  o.collect,
+		multiple: o.multiple,
+		observer: o.observer,
+		logger:   o.logger,
+		warnings: o.warnings,
+		group:    o.qualify(name),
+		dryRun:   o.dryRun,
+	}
+	for key, files := range o.files {
+		if field, ok := innerName(name, key); ok {
+			if inner.files == nil {
+				inner.files = make(map[string][]*multipart.FileHeader)
+			}
+			inner.files[field] = append(inner.files[field], files...)
+		}
+	}
+	if o.audit != nil {
+		inner.audit = new(AuditRecord)
+	}
+
+	err := p.compiled.parse(ctx, view, &inner)
+	for _, d := range inner.deferred {
+		o.later(groupName(name, d.field), d.apply)
+	}
+	if inner.audit != nil {
+		o.audit.Fields = append(o.audit.Fields, inner.audit.Fields...)
+	}
+	if errs := Errors(nil); errors.As(err, &errs) {
+		return errs
+	}
+	if fe := (*FieldError)(nil); errors.As(err, &fe) {
+		return fe
+	}
+	return err
+}
+
+// innerName returns the name of the field key within the group name, e.g.
+// "address[city]" for "user[address][city]" within "user". It reports false if
+// key is not within the group.
+func innerName(name, key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, name+"[")
+	if !ok {
+		return "", false
+	}
+	i := strings.IndexByte(rest, ']')
+	if i < 0 {
+		return "", false
+	}
+	return rest[:i] + rest[i+1:], true
+}
+
+// groupName returns the complete bracketed name of the field named inner
+// within the group name, e.g. "user[address][city]" for "address[city]".
+func groupName(name, inner string) string {
+	head, tail, _ := strings.Cut(inner, "[")
+	if tail != "" {
+		tail = "[" + tail
+	}
+	return name + "[" + head + "]" + tail
+}
+
+func (p *groupParser) claims(name, key string) bool {
+	return strings.HasPrefix(key, name+"[") && !strings.HasPrefix(key, name+"[]")
+}
+
+// Group is used to extract the fields of schema from form values submitted in
+// the bracket notation of Rails, PHP, and many JavaScript form serializers,
+// where the field named "street" of the group "address" is submitted as
+// "address[street]". Groups may be nested, as in "user[address][street]", and
+// the values of fields accepting multiple values may be submitted with
+// trailing brackets, as in "user[emails][]". Errors, warnings, observers,
+// loggers, and audit records name the field with its complete bracketed name.
+func Group(schema Schema) Parser {
+	return &groupParser{
+		schema:   schema,
+		compiled: compile(schema),
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"errors"
+	"log/slog"
+	"mime/multipart"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+)
+
+type groupUser struct {
+	Name   string
+	Emails []string
+	City   string
+	Zip    int
+}
+
+func bindGroupUser(u *groupUser) Schema {
+	return Schema{
+		"user": Group(Schema{
+			"name":   String(&u.Name),
+			"emails": Strings(&u.Emails),
+			"address": Group(Schema{
+				"city": String(&u.City),
+				"zip":  IntOr(&u.Zip, 0),
+			}),
+		}),
+	}
+}
+
+func Test_Parse_Group(t *testing.T) {
+	t.Parallel()
+
+	var u groupUser
+	err := ParseValues(url.Values{
+		"user[name]":            {"bob"},
+		"user[emails][]":        {"bob@example.com", "b@example.org"},
+		"user[address][city]":   {"Springfield"},
+		"user[address][zip]":    {"12345"},
+		"user[address][extra]x": {"ignored"},
+	}, bindGroupUser(&u))
+	must.NoError(t, err)
+	must.Eq(t, groupUser{
+		Name:   "bob",
+		Emails: []string{"bob@example.com", "b@example.org"},
+		City:   "Springfield",
+		Zip:    12345,
+	}, u)
+}
+
+func Test_Parse_Group_errors(t *testing.T) {
+	t.Parallel()
+
+	var u groupUser
+	data := url.Values{
+		"user[emails][]":     {"bob@example.com"},
+		"user[address][zip]": {"abc"},
+	}
+
+	err := ParseValues(data, bindGroupUser(&u))
+	var fe *FieldError
+	must.True(t, errors.As(err, &fe))
+	must.Eq(t, "user[address][city]", fe.Field)
+	must.ErrorIs(t, err, ErrNoValue)
+
+	err = ParseValues(data, bindGroupUser(&u), CollectAllErrors())
+	var errs Errors
+	must.True(t, errors.As(err, &errs))
+	fields := make([]string, len(errs))
+	for i, fe := range errs {
+		fields[i] = fe.Field
+	}
+	must.Eq(t, []string{"user[address][city]", "user[address][zip]", "user[name]"}, fields)
+}
+
+func Test_Parse_Group_strict(t *testing.T) {
+	t.Parallel()
+
+	var u groupUser
+	err := ParseValues(url.Values{
+		"user[name]":          {"bob"},
+		"user[emails][]":      {"bob@example.com"},
+		"user[address][city]": {"Springfield"},
+		"user[admin]":         {"true"},
+	}, bindGroupUser(&u), Strict())
+	must.ErrorIs(t, err, ErrUnexpectedField)
+	must.StrContains(t, err.Error(), "user[admin]")
+}
+
+func Test_Parse_brackets(t *testing.T) {
+	t.Parallel()
+
+	var tags []string
+	err := ParseValues(url.Values{"tags": {"a"}, "tags[]": {"b", "c"}}, Schema{
+		"tags": Strings(&tags),
+	}, Strict())
+	must.NoError(t, err)
+	must.Eq(t, []string{"a", "b", "c"}, tags)
+
+	var name string
+	err = ParseValues(url.Values{"name[]": {"bob"}}, Schema{
+		"name": StringOr(&name, ""),
+	}, Strict())
+	must.ErrorIs(t, err, ErrUnexpectedField)
+}
+
+func Test_Parse_Group_reporting(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sb       strings.Builder
+		observed []string
+		warnings []Warning
+		street   string
+		fax      string
+		n        int
+	)
+	logger := slog.New(slog.NewTextHandler(&sb, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	err := ParseValues(url.Values{
+		"address[street]": {"1 Main St"},
+		"address[fax]":    {"555-1234"},
+		"address[n]":      {"x"},
+	}, Schema{
+		"address": Group(Schema{
+			"street": String(&street),
+			"fax":    Deprecated(StringOr(&fax, ""), "fax numbers are no longer used"),
+			"n":      Int(&n),
+		}),
+	}, WithObserver(func(field string, _ error, _ time.Duration) {
+		observed = append(observed, field)
+	}), WithLogger(logger), CollectWarnings(&warnings))
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+	must.StrContains(t, err.Error(), "address[n]: ")
+
+	// the group itself is not reported, only each of its fields
+	must.Eq(t, []string{"address[fax]", "address[n]", "address[street]"}, observed)
+	must.Eq(t, []Warning{{Field: "address[fax]", Message: "fax numbers are no longer used"}}, warnings)
+	must.StrContains(t, sb.String(), "field=address[n] ")
+	must.StrNotContains(t, sb.String(), "field=address ")
+}
+
+func Test_Parse_Group_File(t *testing.T) {
+	t.Parallel()
+
+	r := postMultipart(t, map[string]string{"profile[name]": "bob"},
+		upload{field: "profile[photo]", filename: "cat.png", content: pngHeader},
+	)
+
+	var (
+		name  string
+		photo *multipart.FileHeader
+	)
+	err := Parse(r, Schema{
+		"profile": Group(Schema{
+			"name":  String(&name),
+			"photo": File(&photo, AllowTypes("image/png")),
+		}),
+	}, Strict())
+	must.NoError(t, err)
+	must.Eq(t, "bob", name)
+	must.Eq(t, "cat.png", photo.Filename)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// registry maps the reflect.Type of a form to its *Definition, created either
+// by Register or from the form tags of the type.
+var registry sync.Map
+
+// Register records bind as the way of producing a Schema for forms of type T,
+// for use by HandlerFunc. Registering a type again replaces its bind function.
+func Register[T any](bind func(*T) Schema) {
+	registry.Store(reflect.TypeFor[T](), Define(bind))
+}
+
+// lookup returns the Definition registered for T or, if there is none and T is
+// a struct with form tags, a Definition binding T with Bind.
+func lookup[T any]() (*Definition[T], error) {
+	t := reflect.TypeFor[T]()
+	if d, ok := registry.Load(t); ok {
+		return d.(*Definition[T]), nil
+	}
+
+	schema, err := Bind(new(T))
+	switch {
+	case err != nil && t.Kind() == reflect.Struct:
+		return nil, fmt.Errorf("%w: %s: %w", ErrNotRegistered, t, err)
+	case err != nil || len(schema) == 0:
+		return nil, fmt.Errorf("%w: %s", ErrNotRegistered, t)
+	}
+	d, _ := registry.LoadOrStore(t, Define(func(form *T) Schema {
+		// Bind cannot fail for a type it has already bound
+		schema, _ := Bind(form)
+		return schema
+	}))
+	return d.(*Definition[T]), nil
+}
+
+// HandlerFunc returns an http.Handler which parses the form of each request
+// into a new T using the Schema registered for T, and then calls fn with the
+// result. If no Schema is registered for T and T is a struct with form tags,
+// the form is parsed into T as by Bind. If the form fails to parse, the
+// request is rejected with status http.StatusBadRequest and fn is not called.
+// If T is neither registered nor tagged, the request fails with status
+// http.StatusInternalServerError. The opts are applied to the parse of every
+// request.
+func HandlerFunc[T any](fn func(w http.ResponseWriter, r *http.Request, form T), opts ...Option) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		definition, err := lookup[T]()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		form, err := definition.Parse(r, opts...)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		fn(w, r, *form)
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+type login struct {
+	User string
+}
+
+func Test_HandlerFunc(t *testing.T) {
+	t.Parallel()
+
+	Register(func(l *login) Schema {
+		return Schema{"user": String(&l.User)}
+	})
+
+	var got login
+	handler := HandlerFunc(func(_ http.ResponseWriter, _ *http.Request, form login) {
+		got = form
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, postForm(t, url.Values{"user": {"alice"}}))
+	must.Eq(t, http.StatusOK, w.Code)
+	must.Eq(t, login{User: "alice"}, got)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, postForm(t, url.Values{}))
+	must.Eq(t, http.StatusBadRequest, w.Code)
+}
+
+func Test_HandlerFunc_options(t *testing.T) {
+	t.Parallel()
+
+	type account struct {
+		Email string
+	}
+	Register(func(a *account) Schema {
+		return Schema{"email": String(&a.Email)}
+	})
+
+	called := false
+	handler := HandlerFunc(func(http.ResponseWriter, *http.Request, account) {
+		called = true
+	}, MaxBytes(16))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, postForm(t, url.Values{"email": {"someone@example.com"}}))
+	must.Eq(t, http.StatusBadRequest, w.Code)
+	must.StrContains(t, w.Body.String(), ErrBodyTooLarge.Error())
+	must.False(t, called)
+}
+
+func Test_HandlerFunc_not_registered(t *testing.T) {
+	t.Parallel()
+
+	type unregistered struct{}
+
+	called := false
+	handler := HandlerFunc(func(http.ResponseWriter, *http.Request, unregistered) {
+		called = true
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, postForm(t, url.Values{}))
+	must.Eq(t, http.StatusInternalServerError, w.Code)
+	must.False(t, called)
+}
+
+func Test_HandlerFunc_tags(t *testing.T) {
+	t.Parallel()
+
+	type contact struct {
+		Name  string `form:"name"`
+		Phone string `form:"phone,optional"`
+	}
+
+	var got contact
+	handler := HandlerFunc(func(_ http.ResponseWriter, _ *http.Request, form contact) {
+		got = form
+	}, Strict())
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, postForm(t, url.Values{"name": {"bob"}}))
+	must.Eq(t, http.StatusOK, w.Code)
+	must.Eq(t, contact{Name: "bob"}, got)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, postForm(t, url.Values{"name": {"bob"}, "extra": {"1"}}))
+	must.Eq(t, http.StatusBadRequest, w.Code)
+
+	type unsupported struct {
+		Ch chan int `form:"ch"`
+	}
+	w = httptest.NewRecorder()
+	HandlerFunc(func(http.ResponseWriter, *http.Request, unsupported) {}).ServeHTTP(w, postForm(t, url.Values{}))
+	must.Eq(t, http.StatusInternalServerError, w.Code)
+	must.StrContains(t, w.Body.String(), ErrUnsupportedType.Error())
+}
@@ -0,0 +1,95 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type idListParser[T IntType] struct {
+	maxItems    int
+	destination *[]T
+}
+
+func (p *idListParser[T]) describe() field {
+	f := field{
+		kind:        "id list",
+		required:    true,
+		multiple:    true,
+		constraints: []string{"submitted repeatedly or as comma separated values"},
+	}
+	if p.maxItems > 0 {
+		f.constraints = append(f.constraints, fmt.Sprintf("at most %d ids", p.maxItems))
+	}
+	return f
+}
+
+func (p *idListParser[T]) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *idListParser[T]) Parse(values []string) error {
+	var ids []T
+	seen := make(map[T]bool)
+	index := 0
+	for _, value := range values {
+		for entry := range strings.SplitSeq(value, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			id, err := parseID[T](entry)
+			if err != nil {
+				return fmt.Errorf("element %d: %w", index, err)
+			}
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+			// fail before reading the rest of an oversized submission
+			if p.maxItems > 0 && len(ids) > p.maxItems {
+				return fmt.Errorf("%w: limit is %d", ErrTooManyValues, p.maxItems)
+			}
+			index++
+		}
+	}
+
+	if len(ids) == 0 {
+		return ErrNoValue
+	}
+
+	*p.destination = ids
+	return nil
+}
+
+// parseID parses value as a T, rejecting values which overflow T.
+func parseID[T IntType](value string) (T, error) {
+	t := reflect.TypeFor[T]()
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, t.Bits())
+		return T(u), err
+	default:
+		i, err := strconv.ParseInt(value, 10, t.Bits())
+		return T(i), err
+	}
+}
+
+// IDList is used to extract a list of integer IDs, as submitted by a bulk
+// action form, into a slice of Go integers. The IDs may be submitted as
+// repeated form values, as comma separated values, or both, and are
+// deduplicated keeping the order in which they were first submitted.
+//
+// If no IDs are submitted, an ID is not an integer of type T, or there are
+// more than maxItems unique IDs (when maxItems is positive), then an error is
+// returned during parsing. The error of an invalid ID includes its index.
+func IDList[T IntType](dst *[]T, maxItems int) Parser {
+	return &idListParser[T]{
+		maxItems:    maxItems,
+		destination: dst,
+	}
+}
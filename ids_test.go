@@ -0,0 +1,77 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_IDList(t *testing.T) {
+	t.Parallel()
+
+	var ids []int64
+	err := ParseValues(url.Values{"id": {"3", "1,2", " 3 , 4,", "1"}}, Schema{
+		"id": IDList(&ids, 10),
+	})
+	must.NoError(t, err)
+	must.Eq(t, []int64{3, 1, 2, 4}, ids)
+
+	var small []uint8
+	err = ParseValues(url.Values{"id[]": {"7", "255"}}, Schema{
+		"id": IDList(&small, 0),
+	})
+	must.NoError(t, err)
+	must.Eq(t, []uint8{7, 255}, small)
+}
+
+func Test_Parse_IDList_invalid(t *testing.T) {
+	t.Parallel()
+
+	var ids []int
+	schema := Schema{"id": IDList(&ids, 3)}
+
+	err := ParseValues(url.Values{"id": {"1,2", "x"}}, schema)
+	must.ErrorContains(t, err, "element 2")
+
+	err = ParseValues(url.Values{"id": {"1,2,3,4"}}, schema)
+	must.ErrorIs(t, err, ErrTooManyValues)
+
+	err = ParseValues(url.Values{"id": {"1,1,2,2,3,3"}}, schema)
+	must.NoError(t, err)
+	must.Eq(t, []int{1, 2, 3}, ids)
+
+	err = ParseValues(url.Values{"id": {","}}, schema)
+	must.ErrorIs(t, err, ErrNoValue)
+
+	var small []uint8
+	err = ParseValues(url.Values{"id": {"-1"}}, Schema{"id": IDList(&small, 0)})
+	must.ErrorContains(t, err, "element 0")
+	err = ParseValues(url.Values{"id": {"256"}}, Schema{"id": IDList(&small, 0)})
+	must.Error(t, err)
+}
+
+func Test_Parse_IDList_large(t *testing.T) {
+	t.Parallel()
+
+	entries := make([]string, 60_000)
+	for i := range entries {
+		entries[i] = strconv.Itoa(i)
+	}
+	// the limit is reached before the malformed entry is read
+	value := strings.Join(entries, ",") + ",x"
+
+	var ids []int
+	err := ParseValues(url.Values{"id": {value}}, Schema{"id": IDList(&ids, 100)})
+	must.ErrorIs(t, err, ErrTooManyValues)
+	must.SliceEmpty(t, ids)
+
+	err = ParseValues(url.Values{"id": {strings.Join(entries, ",")}}, Schema{"id": IDList(&ids, 0)})
+	must.NoError(t, err)
+	must.SliceLen(t, 60_000, ids)
+}
@@ -0,0 +1,166 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"strings"
+)
+
+// countryCodes contains every officially assigned ISO 3166-1 alpha-2 code.
+var countryCodes = codeSet(`
+	AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ
+	BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ
+	CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ
+	DE DJ DK DM DO DZ
+	EC EE EG EH ER ES ET
+	FI FJ FK FM FO FR
+	GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY
+	HK HM HN HR HT HU
+	ID IE IL IM IN IO IQ IR IS IT
+	JE JM JO JP
+	KE KG KH KI KM KN KP KR KW KY KZ
+	LA LB LC LI LK LR LS LT LU LV LY
+	MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW MX MY MZ
+	NA NC NE NF NG NI NL NO NP NR NU NZ
+	OM
+	PA PE PF PG PH PK PL PM PN PR PS PT PW PY
+	QA
+	RE RO RS RU RW
+	SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ
+	TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ
+	UA UG UM US UY UZ
+	VA VC VE VG VI VN VU
+	WF WS
+	YE YT
+	ZA ZM ZW
+`)
+
+// currencyCodes contains the ISO 4217 codes of circulating currencies. Fund
+// codes, precious metals, and the testing and "no currency" codes are
+// deliberately excluded, as they are never valid in a billing form.
+var currencyCodes = codeSet(`
+	AED AFN ALL AMD ANG AOA ARS AUD AWG AZN
+	BAM BBD BDT BGN BHD BIF BMD BND BOB BRL BSD BTN BWP BYN BZD
+	CAD CDF CHF CLP CNY COP CRC CUP CVE CZK
+	DJF DKK DOP DZD
+	EGP ERN ETB EUR
+	FJD FKP
+	GBP GEL GHS GIP GMD GNF GTQ GYD
+	HKD HNL HTG HUF
+	IDR ILS INR IQD IRR ISK
+	JMD JOD JPY
+	KES KGS KHR KMF KPW KRW KWD KYD KZT
+	LAK LBP LKR LRD LSL LYD
+	MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MYR MZN
+	NAD NGN NIO NOK NPR NZD
+	OMR
+	PAB PEN PGK PHP PKR PLN PYG
+	QAR
+	RON RSD RUB RWF
+	SAR SBD SCR SDG SEK SGD SHP SLE SLL SOS SRD SSP STN SVC SYP SZL
+	THB TJS TMT TND TOP TRY TTD TWD TZS
+	UAH UGX USD UYU UZS
+	VED VES VND VUV
+	WST
+	XAF XCD XCG XDR XOF XPF
+	YER
+	ZAR ZMW ZWG ZWL
+`)
+
+func codeSet(codes string) map[string]bool {
+	set := make(map[string]bool)
+	for _, code := range strings.Fields(codes) {
+		set[code] = true
+	}
+	return set
+}
+
+type codeParser[T StringType] struct {
+	kind        string
+	codes       map[string]bool
+	required    bool
+	alt         T
+	destination *T
+}
+
+func (p *codeParser[T]) describe() field {
+	return field{kind: p.kind, required: p.required, alt: p.alt}
+}
+
+func (p *codeParser[T]) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *codeParser[T]) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(values[0]))
+	if !p.codes[code] {
+		return ErrUnknownCode
+	}
+
+	*p.destination = T(code)
+	return nil
+}
+
+// CountryCode is used to extract a form data value containing an ISO 3166-1
+// alpha-2 country code into a Go string. The code is normalized to upper case.
+// If the value is not an assigned country code or is missing then an error is
+// returned during parsing.
+func CountryCode[T StringType](s *T) Parser {
+	return &codeParser[T]{
+		kind:        "country code",
+		codes:       countryCodes,
+		required:    true,
+		destination: s,
+	}
+}
+
+// CountryCodeOr is used to extract a form data value containing an ISO 3166-1
+// alpha-2 country code into a Go string. If the value is missing, then the alt
+// value is used instead.
+func CountryCodeOr[T StringType](s *T, alt T) Parser {
+	*s = alt
+	return &codeParser[T]{
+		kind:        "country code",
+		codes:       countryCodes,
+		required:    false,
+		alt:         alt,
+		destination: s,
+	}
+}
+
+// CurrencyCode is used to extract a form data value containing an ISO 4217
+// currency code into a Go string. The code is normalized to upper case. If the
+// value is not the code of a circulating currency or is missing then an error
+// is returned during parsing.
+func CurrencyCode[T StringType](s *T) Parser {
+	return &codeParser[T]{
+		kind:        "currency code",
+		codes:       currencyCodes,
+		required:    true,
+		destination: s,
+	}
+}
+
+// CurrencyCodeOr is used to extract a form data value containing an ISO 4217
+// currency code into a Go string. If the value is missing, then the alt value
+// is used instead.
+func CurrencyCodeOr[T StringType](s *T, alt T) Parser {
+	*s = alt
+	return &codeParser[T]{
+		kind:        "currency code",
+		codes:       currencyCodes,
+		required:    false,
+		alt:         alt,
+		destination: s,
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_codeSet_sizes(t *testing.T) {
+	t.Parallel()
+
+	must.MapLen(t, 249, countryCodes)
+	must.MapContainsKeys(t, currencyCodes, []string{"USD", "EUR", "JPY", "GBP"})
+	must.MapNotContainsKeys(t, currencyCodes, []string{"XXX", "XTS", "XAU"})
+}
+
+func Test_Parse_CountryCode(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"country":  []string{"us"},
+		"currency": []string{" eur "},
+	}
+
+	var country, currency, shipping, billing string
+	err := ParseValues(data, Schema{
+		"country":  CountryCode(&country),
+		"currency": CurrencyCode(&currency),
+		"shipping": CountryCodeOr(&shipping, "CA"),
+		"billing":  CurrencyCodeOr(&billing, "USD"),
+	})
+	must.NoError(t, err)
+	must.Eq(t, "US", country)
+	must.Eq(t, "EUR", currency)
+	must.Eq(t, "CA", shipping)
+	must.Eq(t, "USD", billing)
+}
+
+func Test_Parse_CountryCode_unknown(t *testing.T) {
+	t.Parallel()
+
+	var country, currency string
+	err := ParseValues(url.Values{"country": {"XX"}}, Schema{
+		"country": CountryCode(&country),
+	})
+	must.ErrorIs(t, err, ErrUnknownCode)
+
+	err = ParseValues(url.Values{"currency": {"XAU"}}, Schema{
+		"currency": CurrencyCode(&currency),
+	})
+	must.ErrorIs(t, err, ErrUnknownCode)
+
+	err = ParseValues(url.Values{}, Schema{
+		"currency": CurrencyCode(&currency),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
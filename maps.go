@@ -0,0 +1,113 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+type mapParser struct {
+	required    bool
+	alt         map[string]string
+	destination *map[string]string
+}
+
+func (p *mapParser) describe() field {
+	return field{
+		kind:        "map",
+		required:    p.required,
+		alt:         p.alt,
+		constraints: []string{"submitted as name.key or name[key]"},
+	}
+}
+
+func (p *mapParser) destinations() []any {
+	return []any{p.destination}
+}
+
+// Parse implements Parser. A map parser only sees prefixed fields when used
+// in a Schema, so on its own it behaves as though none were submitted.
+func (p *mapParser) Parse([]string) error {
+	return p.parseForm(context.Background(), "", nil, new(options))
+}
+
+func (p *mapParser) parseForm(_ context.Context, prefix string, data url.Values, _ *options) error {
+	m := make(map[string]string)
+	for name, values := range data {
+		key, ok := mapKey(prefix, name)
+		if !ok {
+			continue
+		}
+		_, exists := m[key]
+		switch {
+		case len(values) > 1 || (len(values) == 1 && exists):
+			// prefix.key and prefix[key] name the same entry
+			return ErrMulitpleValues
+		case len(values) == 0:
+			continue
+		}
+		m[key] = values[0]
+	}
+
+	switch {
+	case len(m) == 0 && p.required:
+		return ErrNoValue
+	case len(m) == 0:
+		return nil
+	default:
+		*p.destination = m
+	}
+	return nil
+}
+
+func (p *mapParser) claims(prefix, key string) bool {
+	_, ok := mapKey(prefix, key)
+	return ok
+}
+
+// mapKey extracts the key of a field named in the form "prefix.key" or
+// "prefix[key]".
+func mapKey(prefix, name string) (string, bool) {
+	rest, ok := strings.CutPrefix(name, prefix)
+	if !ok || prefix == "" {
+		return "", false
+	}
+
+	switch {
+	case len(rest) > 1 && rest[0] == '.':
+		return rest[1:], true
+	case len(rest) > 2 && rest[0] == '[' && rest[len(rest)-1] == ']':
+		return rest[1 : len(rest)-1], true
+	default:
+		return "", false
+	}
+}
+
+// Map is used to extract every form data value whose name is prefixed by the
+// schema field name into a Go map. Fields may be named either "prefix.key" or
+// "prefix[key]", and the map is keyed by the key part of the name.
+//
+// If no such form values exist, or a key is submitted more than once under
+// either spelling, then an error is returned during parsing.
+func Map(m *map[string]string) Parser {
+	return &mapParser{
+		required:    true,
+		destination: m,
+	}
+}
+
+// MapOr is used to extract every form data value whose name is prefixed by the
+// schema field name into a Go map.
+//
+// If no such form values exist, then the given alt value is used instead.
+func MapOr(m *map[string]string, alt map[string]string) Parser {
+	*m = alt
+	return &mapParser{
+		required:    false,
+		alt:         alt,
+		destination: m,
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_Map_dotted(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"meta.color": []string{"red"},
+		"meta.size":  []string{"large"},
+		"other":      []string{"x"},
+	}
+
+	var meta map[string]string
+	err := ParseValues(data, Schema{
+		"meta": Map(&meta),
+	})
+	must.NoError(t, err)
+	must.Eq(t, map[string]string{"color": "red", "size": "large"}, meta)
+}
+
+func Test_Parse_Map_brackets(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"meta[color]": []string{"red"},
+		"meta.size":   []string{"large"},
+		"metadata":    []string{"x"},
+		"meta[]":      []string{"y"},
+	}
+
+	var meta map[string]string
+	err := ParseValues(data, Schema{
+		"meta": Map(&meta),
+	})
+	must.NoError(t, err)
+	must.Eq(t, map[string]string{"color": "red", "size": "large"}, meta)
+}
+
+func Test_Parse_Map_missing(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"other": []string{"x"},
+	}
+
+	var meta map[string]string
+	err := ParseValues(data, Schema{
+		"meta": Map(&meta),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
+
+func Test_Parse_Map_multiple(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"meta.color": []string{"red", "blue"},
+	}
+
+	var meta map[string]string
+	err := ParseValues(data, Schema{
+		"meta": Map(&meta),
+	})
+	must.ErrorIs(t, err, ErrMulitpleValues)
+}
+
+func Test_Parse_Map_collision(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"meta.color":  []string{"red"},
+		"meta[color]": []string{"blue"},
+	}
+
+	var meta map[string]string
+	err := ParseValues(data, Schema{
+		"meta": Map(&meta),
+	})
+	must.ErrorIs(t, err, ErrMulitpleValues)
+	must.Nil(t, meta)
+}
+
+func Test_Parse_MapOr_missing(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"other": []string{"x"},
+	}
+
+	var meta map[string]string
+	err := ParseValues(data, Schema{
+		"meta": MapOr(&meta, map[string]string{"color": "green"}),
+	})
+	must.NoError(t, err)
+	must.Eq(t, map[string]string{"color": "green"}, meta)
+}
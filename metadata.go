@@ -0,0 +1,73 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+// Metadata is presentational information about a field, for use when
+// rendering the field or reporting errors about it to the person filling in
+// the form.
+type Metadata struct {
+	// Label is the human-readable name of the field, e.g. "Email address".
+	Label string
+
+	// Help is a longer description of the expected value.
+	Help string
+
+	// Placeholder is example text to display in an empty input.
+	Placeholder string
+}
+
+// An Annotation sets one element of the Metadata of a field.
+type Annotation func(*Metadata)
+
+// Label sets the human-readable name of a field. The label is used in place of
+// the field name in the message of a FieldError.
+func Label(label string) Annotation {
+	return func(m *Metadata) {
+		m.Label = label
+	}
+}
+
+// Help sets the help text of a field.
+func Help(help string) Annotation {
+	return func(m *Metadata) {
+		m.Help = help
+	}
+}
+
+// Placeholder sets the placeholder text of a field.
+func Placeholder(placeholder string) Annotation {
+	return func(m *Metadata) {
+		m.Placeholder = placeholder
+	}
+}
+
+type annotatedParser struct {
+	Parser
+	meta Metadata
+}
+
+func (p *annotatedParser) describe() field {
+	f := describe(p.Parser)
+	f.meta = p.meta
+	return f
+}
+
+func (p *annotatedParser) destinations() []any {
+	return destinations(p.Parser)
+}
+
+// Annotate wraps p with the Metadata set by annotations, which is reported by
+// Schema.Fields and Markdown, and used in the messages of errors.
+func Annotate(p Parser, annotations ...Annotation) Parser {
+	a := &annotatedParser{
+		Parser: p,
+	}
+	if inner, ok := p.(*annotatedParser); ok {
+		a.Parser, a.meta = inner.Parser, inner.meta
+	}
+	for _, annotate := range annotations {
+		annotate(&a.meta)
+	}
+	return a
+}
@@ -0,0 +1,75 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Annotate_errors(t *testing.T) {
+	t.Parallel()
+
+	var email string
+	schema := Schema{
+		"email": Annotate(String(&email), Label("Email address")),
+	}
+
+	err := ParseValues(url.Values{}, schema)
+	must.ErrorIs(t, err, ErrNoValue)
+	must.EqError(t, err, "could not parse value: Email address: expected value to exist")
+
+	var fe *FieldError
+	must.True(t, errors.As(err, &fe))
+	must.Eq(t, "email", fe.Field)
+	must.Eq(t, "Email address", fe.Label)
+
+	err = ParseValues(url.Values{}, schema, CollectAllErrors())
+	must.EqError(t, err, "could not parse value: Email address: expected value to exist")
+}
+
+func Test_Annotate_parses(t *testing.T) {
+	t.Parallel()
+
+	var (
+		email string
+		meta  map[string]string
+	)
+
+	err := ParseValues(url.Values{"email": {"bob@example.com"}, "meta.k": {"v"}}, Schema{
+		"email": Annotate(String(&email), Label("Email address")),
+		"meta":  Annotate(Map(&meta), Label("Metadata")),
+	}, Strict())
+	must.NoError(t, err)
+	must.Eq(t, "bob@example.com", email)
+	must.Eq(t, map[string]string{"k": "v"}, meta)
+}
+
+func Test_Annotate_Fields(t *testing.T) {
+	t.Parallel()
+
+	var email string
+	p := Annotate(String(&email), Label("Email address"), Help("Where we send receipts."))
+	p = Annotate(p, Placeholder("you@example.com"))
+
+	schema := Schema{"email": p}
+	must.Eq(t, []FieldInfo{{
+		Name:     "email",
+		Kind:     "string",
+		Required: true,
+		Metadata: Metadata{
+			Label:       "Email address",
+			Help:        "Where we send receipts.",
+			Placeholder: "you@example.com",
+		},
+	}}, schema.Fields())
+
+	var sb strings.Builder
+	must.NoError(t, Markdown(&sb, schema))
+	must.StrContains(t, sb.String(), "| `email` (Email address) | string | yes |  | Where we send receipts. |\n")
+}
@@ -0,0 +1,64 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"context"
+	"net/http"
+)
+
+type contextKey struct{}
+
+type contextValue struct {
+	form any
+	err  error
+}
+
+// Middleware returns HTTP middleware which parses the form of each request
+// before the wrapped handler runs. For every request a new T is allocated and
+// passed to bind, which returns the Schema describing how the form is parsed
+// into the fields of T. The parsed T, or the error from parsing it, is stored
+// in the request context and is retrieved using FromContext.
+//
+// If status is non-zero, requests whose form fails to parse are rejected with
+// that status code (e.g. http.StatusBadRequest or http.StatusUnprocessableEntity)
+// and the wrapped handler is not called. Otherwise the wrapped handler is
+// always called, and is responsible for checking the error.
+//
+// The opts are applied to the parse of every request, e.g. MaxBytes or Strict.
+func Middleware[T any](bind func(*T) Schema, status int, opts ...Option) func(http.Handler) http.Handler {
+	definition := Define(bind)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			form, err := definition.Parse(r, opts...)
+			if err != nil && status != 0 {
+				http.Error(w, err.Error(), status)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), contextKey{}, contextValue{
+				form: form,
+				err:  err,
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// FromContext returns the form parsed by Middleware and stored in ctx, along
+// with any error which occurred while parsing it. If ctx does not contain a
+// form of type T, ErrNoForm is returned.
+func FromContext[T any](ctx context.Context) (*T, error) {
+	value, ok := ctx.Value(contextKey{}).(contextValue)
+	if !ok {
+		return nil, ErrNoForm
+	}
+
+	form, ok := value.form.(*T)
+	if !ok {
+		return nil, ErrNoForm
+	}
+
+	return form, value.err
+}
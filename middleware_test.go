@@ -0,0 +1,109 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+type signup struct {
+	Name string
+	Age  int
+}
+
+func bindSignup(s *signup) Schema {
+	return Schema{
+		"name": String(&s.Name),
+		"age":  IntOr(&s.Age, 18),
+	}
+}
+
+func postForm(t *testing.T, data url.Values) *http.Request {
+	request := httptest.NewRequestWithContext(
+		t.Context(), http.MethodPost, "/", strings.NewReader(data.Encode()),
+	)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return request
+}
+
+func Test_Middleware(t *testing.T) {
+	t.Parallel()
+
+	var form *signup
+	handler := Middleware(bindSignup, http.StatusBadRequest)(
+		http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			var err error
+			form, err = FromContext[signup](r.Context())
+			must.NoError(t, err)
+		}),
+	)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, postForm(t, url.Values{"name": {"bob"}}))
+	must.Eq(t, http.StatusOK, w.Code)
+	must.Eq(t, &signup{Name: "bob", Age: 18}, form)
+}
+
+func Test_Middleware_reject(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	handler := Middleware(bindSignup, http.StatusUnprocessableEntity)(
+		http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			called = true
+		}),
+	)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, postForm(t, url.Values{"age": {"20"}}))
+	must.Eq(t, http.StatusUnprocessableEntity, w.Code)
+	must.False(t, called)
+}
+
+func Test_Middleware_passthrough(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	handler := Middleware(bindSignup, 0)(
+		http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			_, err = FromContext[signup](r.Context())
+		}),
+	)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, postForm(t, url.Values{"age": {"20"}}))
+	must.Eq(t, http.StatusOK, w.Code)
+	must.ErrorIs(t, err, ErrNoValue)
+}
+
+func Test_Middleware_options(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	handler := Middleware(bindSignup, http.StatusBadRequest, Strict())(
+		http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			called = true
+		}),
+	)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, postForm(t, url.Values{"name": {"bob"}, "extra": {"1"}}))
+	must.Eq(t, http.StatusBadRequest, w.Code)
+	must.StrContains(t, w.Body.String(), ErrUnexpectedField.Error())
+	must.False(t, called)
+}
+
+func Test_FromContext_missing(t *testing.T) {
+	t.Parallel()
+
+	form, err := FromContext[signup](t.Context())
+	must.Nil(t, form)
+	must.ErrorIs(t, err, ErrNoForm)
+}
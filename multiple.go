@@ -0,0 +1,75 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"slices"
+)
+
+// A MultiValuePolicy determines how a field which accepts a single value is
+// parsed when the form contains more than one value for it. Parsers which
+// accept many values, such as Strings, are not affected.
+type MultiValuePolicy int
+
+const (
+	// ErrorOnMultiple causes parsing to fail with ErrMulitpleValues. This is
+	// the default policy.
+	ErrorOnMultiple MultiValuePolicy = iota
+
+	// TakeFirst parses only the first of the submitted values.
+	TakeFirst
+
+	// TakeLast parses only the last of the submitted values.
+	TakeLast
+
+	// AllowIdentical parses a single value if every submitted value is the
+	// same, as happens when a page contains duplicated hidden inputs, and
+	// fails with ErrMulitpleValues if the values conflict.
+	AllowIdentical
+)
+
+func (mvp MultiValuePolicy) apply(values []string) []string {
+	switch mvp {
+	case TakeFirst:
+		return values[:1]
+	case TakeLast:
+		return values[len(values)-1:]
+	case AllowIdentical:
+		if !slices.ContainsFunc(values[1:], func(v string) bool { return v != values[0] }) {
+			return values[:1]
+		}
+		return values
+	default:
+		return values
+	}
+}
+
+type policyParser struct {
+	Parser
+	policy MultiValuePolicy
+}
+
+func (p *policyParser) describe() field {
+	return describe(p.Parser)
+}
+
+func (p *policyParser) destinations() []any {
+	return destinations(p.Parser)
+}
+
+func (p *policyParser) Parse(values []string) error {
+	if len(values) > 1 && !describe(p.Parser).multiple {
+		values = p.policy.apply(values)
+	}
+	return p.Parser.Parse(values)
+}
+
+// WithMultiple wraps p so that policy is applied whenever the field it parses
+// is submitted more than once, regardless of the MultipleValues option.
+func WithMultiple(p Parser, policy MultiValuePolicy) Parser {
+	return &policyParser{
+		Parser: p,
+		policy: policy,
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_MultipleValues(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"name":  []string{"alice", "bob"},
+		"names": []string{"carol", "dave"},
+	}
+
+	var (
+		name  string
+		names []string
+	)
+
+	err := ParseValues(data, Schema{"name": String(&name)})
+	must.ErrorIs(t, err, ErrMulitpleValues)
+
+	err = ParseValues(data, Schema{
+		"name":  String(&name),
+		"names": Strings(&names),
+	}, MultipleValues(TakeFirst))
+	must.NoError(t, err)
+	must.Eq(t, "alice", name)
+	must.Eq(t, []string{"carol", "dave"}, names)
+
+	err = ParseValues(data, Schema{"name": String(&name)}, MultipleValues(TakeLast))
+	must.NoError(t, err)
+	must.Eq(t, "bob", name)
+}
+
+func Test_Parse_MultipleValues_AllowIdentical(t *testing.T) {
+	t.Parallel()
+
+	var id, name string
+	err := ParseValues(url.Values{
+		"id":   {"42", "42", "42"},
+		"name": {"alice"},
+	}, Schema{
+		"id":   String(&id),
+		"name": String(&name),
+	}, MultipleValues(AllowIdentical))
+	must.NoError(t, err)
+	must.Eq(t, "42", id)
+	must.Eq(t, "alice", name)
+
+	err = ParseValues(url.Values{"id": {"42", "43"}}, Schema{
+		"id": String(&id),
+	}, MultipleValues(AllowIdentical))
+	must.ErrorIs(t, err, ErrMulitpleValues)
+
+	var one int
+	err = ParseValues(url.Values{"one": {"1", "1"}}, Schema{
+		"one": WithMultiple(Int(&one), AllowIdentical),
+	})
+	must.NoError(t, err)
+	must.Eq(t, 1, one)
+}
+
+func Test_Parse_WithMultiple(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"one": []string{"1", "2"},
+		"two": []string{"3", "4"},
+	}
+
+	var one, two int
+	err := ParseValues(data, Schema{
+		"one": WithMultiple(Int(&one), TakeLast),
+		"two": Int(&two),
+	}, MultipleValues(TakeFirst))
+	must.NoError(t, err)
+	must.Eq(t, 2, one)
+	must.Eq(t, 3, two)
+
+	err = ParseValues(data, Schema{
+		"one": WithMultiple(Int(&one), ErrorOnMultiple),
+	}, MultipleValues(TakeFirst))
+	must.ErrorIs(t, err, ErrMulitpleValues)
+}
+
+func Test_WithMultiple_Parse(t *testing.T) {
+	t.Parallel()
+
+	var s string
+	err := WithMultiple(String(&s), TakeFirst).Parse([]string{"a", "b"})
+	must.NoError(t, err)
+	must.Eq(t, "a", s)
+}
@@ -0,0 +1,156 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+type portParser struct {
+	required    bool
+	lowest      uint16
+	alt         uint16
+	destination *uint16
+}
+
+func (p *portParser) describe() field {
+	return field{
+		kind:        "port",
+		required:    p.required,
+		alt:         p.alt,
+		constraints: []string{fmt.Sprintf("between %d and 65535", p.lowest)},
+	}
+}
+
+func (p *portParser) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *portParser) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	port, err := strconv.ParseUint(values[0], 10, 16)
+	switch {
+	case err != nil:
+		return err
+	case uint16(port) < p.lowest:
+		return ErrOutOfRange
+	}
+
+	*p.destination = uint16(port)
+	return nil
+}
+
+// Port is used to extract a form data value into a Go uint16 representing a
+// TCP or UDP port number. If the value is not a number in the range 1 to 65535
+// or is missing then an error is returned during parsing.
+func Port(p *uint16) Parser {
+	return &portParser{
+		required:    true,
+		lowest:      1,
+		destination: p,
+	}
+}
+
+// PortOr is used to extract a form data value into a Go uint16 representing a
+// TCP or UDP port number. If the value is missing, then the alt value is used
+// instead.
+func PortOr(p *uint16, alt uint16) Parser {
+	*p = alt
+	return &portParser{
+		required:    false,
+		lowest:      1,
+		alt:         alt,
+		destination: p,
+	}
+}
+
+// UnprivilegedPort is used to extract a form data value into a Go uint16
+// representing a TCP or UDP port number, excluding the privileged ports below
+// 1024. If the value is not a number in the range 1024 to 65535 or is missing
+// then an error is returned during parsing.
+func UnprivilegedPort(p *uint16) Parser {
+	return &portParser{
+		required:    true,
+		lowest:      1024,
+		destination: p,
+	}
+}
+
+type macParser struct {
+	required    bool
+	destination *net.HardwareAddr
+}
+
+func (p *macParser) describe() field {
+	return field{
+		kind:        "mac",
+		required:    p.required,
+		constraints: []string{"hardware address separated by colons, hyphens, periods, or nothing"},
+	}
+}
+
+func (p *macParser) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *macParser) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	addr, err := parseMAC(strings.TrimSpace(values[0]))
+	if err != nil {
+		return err
+	}
+
+	*p.destination = addr
+	return nil
+}
+
+// parseMAC parses s in any of the formats accepted by net.ParseMAC, or as the
+// plain hexadecimal digits of an address without any separators.
+func parseMAC(s string) (net.HardwareAddr, error) {
+	addr, err := net.ParseMAC(s)
+	if err == nil {
+		return addr, nil
+	}
+
+	switch len(s) {
+	case 12, 16, 40: // EUI-48, EUI-64, IP over InfiniBand
+		if b, decodeErr := hex.DecodeString(s); decodeErr == nil {
+			return net.HardwareAddr(b), nil
+		}
+	}
+
+	return nil, err
+}
+
+// MAC is used to extract a form data value into a Go net.HardwareAddr. The
+// address may use colons, hyphens, or periods as separators, or no separators
+// at all; the canonical form is available from the String method of the
+// parsed address. If the value is not a hardware address or is missing then
+// an error is returned during parsing.
+func MAC(addr *net.HardwareAddr) Parser {
+	return &macParser{
+		required:    true,
+		destination: addr,
+	}
+}
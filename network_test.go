@@ -0,0 +1,93 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_Port(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"http":  []string{"80"},
+		"admin": []string{"8080"},
+	}
+
+	var http, admin, metrics uint16
+	err := ParseValues(data, Schema{
+		"http":    Port(&http),
+		"admin":   UnprivilegedPort(&admin),
+		"metrics": PortOr(&metrics, 9100),
+	})
+	must.NoError(t, err)
+	must.Eq(t, 80, http)
+	must.Eq(t, 8080, admin)
+	must.Eq(t, 9100, metrics)
+}
+
+func Test_Parse_Port_out_of_range(t *testing.T) {
+	t.Parallel()
+
+	var port uint16
+	err := ParseValues(url.Values{"port": {"0"}}, Schema{
+		"port": Port(&port),
+	})
+	must.ErrorIs(t, err, ErrOutOfRange)
+
+	err = ParseValues(url.Values{"port": {"443"}}, Schema{
+		"port": UnprivilegedPort(&port),
+	})
+	must.ErrorIs(t, err, ErrOutOfRange)
+
+	err = ParseValues(url.Values{"port": {"65536"}}, Schema{
+		"port": Port(&port),
+	})
+	must.Error(t, err)
+
+	err = ParseValues(url.Values{"port": {"-1"}}, Schema{
+		"port": Port(&port),
+	})
+	must.Error(t, err)
+}
+
+func Test_Parse_MAC(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{
+		"00:1a:2b:3c:4d:5e",
+		"00-1A-2B-3C-4D-5E",
+		"001a.2b3c.4d5e",
+		"001A2B3C4D5E",
+	} {
+		var addr net.HardwareAddr
+		err := ParseValues(url.Values{"device": {value}}, Schema{
+			"device": MAC(&addr),
+		})
+		must.NoError(t, err, must.Sprint(value))
+		must.Eq(t, "00:1a:2b:3c:4d:5e", addr.String(), must.Sprint(value))
+	}
+}
+
+func Test_Parse_MAC_malformed(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"", "00:1a:2b", "001A2B3C4D5G", "hello"} {
+		var addr net.HardwareAddr
+		err := ParseValues(url.Values{"device": {value}}, Schema{
+			"device": MAC(&addr),
+		})
+		must.Error(t, err, must.Sprint(value))
+	}
+
+	var addr net.HardwareAddr
+	err := ParseValues(url.Values{}, Schema{
+		"device": MAC(&addr),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
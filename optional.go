@@ -0,0 +1,96 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"fmt"
+)
+
+// An Optional holds a value of type T which may or may not have been set, as
+// the destination of Opt. The zero value is unset.
+type Optional[T any] struct {
+	value T
+	set   bool
+}
+
+// Some returns an Optional which is set to value.
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{value: value, set: true}
+}
+
+// IsSet reports whether o holds a value.
+func (o Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// Get returns the value of o, which is the zero value of T if o is not set.
+func (o Optional[T]) Get() T {
+	return o.value
+}
+
+// OrElse returns the value of o, or def if o is not set.
+func (o Optional[T]) OrElse(def T) T {
+	if !o.set {
+		return def
+	}
+	return o.value
+}
+
+// String returns the value of o formatted with fmt, or "<unset>".
+func (o Optional[T]) String() string {
+	if !o.set {
+		return "<unset>"
+	}
+	return fmt.Sprint(o.value)
+}
+
+type optParser[T any] struct {
+	parser      Parser
+	value       *T
+	destination *Optional[T]
+}
+
+func (p *optParser[T]) describe() field {
+	f := describe(p.parser)
+	f.required = false
+	f.alt = nil
+	return f
+}
+
+func (p *optParser[T]) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *optParser[T]) Parse(values []string) error {
+	if len(values) == 0 || len(values) == 1 && values[0] == "" {
+		*p.destination = Optional[T]{}
+		return nil
+	}
+
+	var zero T
+	*p.value = zero
+	if err := p.parser.Parse(values); err != nil {
+		return err
+	}
+	*p.destination = Some(*p.value)
+	return nil
+}
+
+// Opt is used to extract an optional form data value into a Go Optional, using
+// the Parser created by parser, e.g.
+//
+//	forms.Opt(&form.Nickname, forms.String)
+//
+// If the value is missing or empty then o is left unset, rather than holding a
+// default value; otherwise the value is parsed by the Parser and o is set to
+// the result. Parsers which read the whole form, such as Map, are not
+// supported.
+func Opt[T any](o *Optional[T], parser func(*T) Parser) Parser {
+	value := new(T)
+	return &optParser[T]{
+		parser:      parser(value),
+		value:       value,
+		destination: o,
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Optional(t *testing.T) {
+	t.Parallel()
+
+	var unset Optional[int]
+	must.False(t, unset.IsSet())
+	must.Eq(t, 0, unset.Get())
+	must.Eq(t, 5, unset.OrElse(5))
+	must.Eq(t, "<unset>", unset.String())
+
+	set := Some(3)
+	must.True(t, set.IsSet())
+	must.Eq(t, 3, set.Get())
+	must.Eq(t, 3, set.OrElse(5))
+	must.Eq(t, "3", set.String())
+}
+
+func Test_Parse_Opt(t *testing.T) {
+	t.Parallel()
+
+	var (
+		nickname Optional[string]
+		age      Optional[int]
+		height   Optional[float64]
+		admin    Optional[bool]
+	)
+	schema := Schema{
+		"nickname": Opt(&nickname, String),
+		"age":      Opt(&age, Int),
+		"height":   Opt(&height, Float),
+		"admin":    Opt(&admin, Bool),
+	}
+
+	err := ParseValues(url.Values{
+		"nickname": {"bobby"},
+		"age":      {"0"},
+		"height":   {""},
+	}, schema)
+	must.NoError(t, err)
+	must.Eq(t, Some("bobby"), nickname)
+	must.Eq(t, Some(0), age)
+	must.False(t, height.IsSet())
+	must.False(t, admin.IsSet())
+
+	err = ParseValues(url.Values{}, schema)
+	must.NoError(t, err)
+	must.False(t, nickname.IsSet())
+	must.False(t, age.IsSet())
+
+	err = ParseValues(url.Values{"age": {"old"}}, schema)
+	must.Error(t, err)
+	must.False(t, age.IsSet())
+
+	err = ParseValues(url.Values{"age": {"1", "2"}}, schema)
+	must.ErrorIs(t, err, ErrMulitpleValues)
+
+	fields := schema.Fields()
+	must.Eq(t, "admin", fields[0].Name)
+	must.False(t, fields[0].Required)
+	must.Eq(t, "bool", fields[0].Kind)
+}
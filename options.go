@@ -0,0 +1,301 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"maps"
+	"mime/multipart"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// An Option tunes the behavior of a single call to Parse, ParseValues, or
+// ParseValuesContext.
+type Option func(*options)
+
+type options struct {
+	strict      bool
+	collect     bool
+	maxFields   int
+	maxValueLen int
+	trim        bool
+	maxBytes    int64
+	multiple    MultiValuePolicy
+	text        bool
+	observer    func(string, error, time.Duration)
+	logger      *slog.Logger
+	warnings    *[]Warning
+	files       map[string][]*multipart.FileHeader
+	stream      func(string, io.Reader) error
+	rules       []Rule
+	rest        *url.Values
+	audit       *AuditRecord
+	validator   StructValidator
+	wire        map[string]string
+	group       string
+	dryRun      bool
+	deferred    []deferred
+}
+
+// A deferred is a side effect of parsing a field, such as marking a form token
+// as used, which is applied only once every field of the form has parsed, so
+// that a form which fails may be corrected and submitted again.
+type deferred struct {
+	field string
+	apply func(context.Context) error
+}
+
+// qualify returns the complete name of the field name, which is within the
+// Group being parsed, if any.
+func (o *options) qualify(name string) string {
+	if o.group == "" {
+		return name
+	}
+	return groupName(o.group, name)
+}
+
+// later defers apply until every field of the form has parsed.
+func (o *options) later(field string, apply func(context.Context) error) {
+	o.deferred = append(o.deferred, deferred{field: field, apply: apply})
+}
+
+func (o *options) warn(name string, w *Warning) {
+	if o.warnings == nil {
+		return
+	}
+	if w.Field == "" {
+		w.Field = name
+	}
+	*o.warnings = append(*o.warnings, *w)
+}
+
+func newOptions(opts []Option) *options {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Strict causes parsing to fail if the form contains any field which is not
+// read by a parser of the schema.
+func Strict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// CollectAllErrors causes parsing to continue past the first field that fails,
+// returning an Errors describing every failure rather than only the first.
+func CollectAllErrors() Option {
+	return func(o *options) {
+		o.collect = true
+	}
+}
+
+// MaxFields causes parsing to fail with ErrTooManyFields if the form contains
+// more than n distinct field names.
+func MaxFields(n int) Option {
+	return func(o *options) {
+		o.maxFields = n
+	}
+}
+
+// MaxValueLen causes parsing to fail with ErrValueTooLong if any value of the
+// form is longer than n bytes. As with MaxFields, the check is made before any
+// field is parsed.
+func MaxValueLen(n int) Option {
+	return func(o *options) {
+		o.maxValueLen = n
+	}
+}
+
+// longValue reports the first field name, in order, with a value longer than
+// limit bytes.
+func longValue(data url.Values, limit int) (string, bool) {
+	key, found := "", false
+	for k, values := range data {
+		if found && k >= key {
+			continue
+		}
+		if slices.ContainsFunc(values, func(v string) bool { return len(v) > limit }) {
+			key, found = k, true
+		}
+	}
+	return key, found
+}
+
+// TrimAll causes leading and trailing white space to be removed from every
+// form value before it is parsed.
+func TrimAll() Option {
+	return func(o *options) {
+		o.trim = true
+	}
+}
+
+// Wire causes the form field submitted as wire to be parsed as the schema field
+// name, allowing the schema to keep its canonical names while the names used
+// by a frontend change independently. Prefixed fields such as "wire[key]" and
+// "wire.key" are renamed in the same way, for use with Map and Group. Errors
+// are reported under the schema field name. If a form contains both wire and
+// name, their values are combined.
+func Wire(wire, name string) Option {
+	return func(o *options) {
+		if o.wire == nil {
+			o.wire = make(map[string]string)
+		}
+		o.wire[wire] = name
+	}
+}
+
+// renameWire returns a copy of data with each wire field renamed to its
+// schema field name.
+func renameWire(data url.Values, wire map[string]string) url.Values {
+	renamed := make(url.Values, len(data))
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		name := wireName(key, wire)
+		renamed[name] = append(renamed[name], data[key]...)
+	}
+	return renamed
+}
+
+// wireName returns the schema field name of the form field key.
+func wireName(key string, wire map[string]string) string {
+	if name, exists := wire[key]; exists {
+		return name
+	}
+	if i := strings.IndexAny(key, "[."); i > 0 {
+		if name, exists := wire[key[:i]]; exists {
+			return name + key[i:]
+		}
+	}
+	return key
+}
+
+// MaxBytes limits the size of the request body read by Parse to n bytes. If the
+// body is larger, parsing fails with ErrBodyTooLarge. MaxBytes has no effect
+// on ParseValues, which is given form values that have already been read.
+func MaxBytes(n int64) Option {
+	return func(o *options) {
+		o.maxBytes = n
+	}
+}
+
+// MultipleValues sets the policy applied when a field accepting a single value
+// is submitted more than once. Parsers wrapped by WithMultiple keep their own
+// policy.
+func MultipleValues(policy MultiValuePolicy) Option {
+	return func(o *options) {
+		o.multiple = policy
+	}
+}
+
+// StrictText causes parsing to fail with ErrInvalidText if any form value is not
+// valid UTF-8, or contains a NUL byte or any other control character besides
+// tab, line feed, and carriage return.
+func StrictText() Option {
+	return func(o *options) {
+		o.text = true
+	}
+}
+
+// WithObserver causes fn to be called after each field of the schema is parsed,
+// with the name of the field, the error from parsing it (nil on success), and
+// the time parsing took. It is intended for counting failures and measuring
+// latency, e.g. through metrics or OpenTelemetry.
+func WithObserver(fn func(field string, err error, dur time.Duration)) Option {
+	return func(o *options) {
+		o.observer = fn
+	}
+}
+
+// WithLogger causes each field which fails to parse to be logged to logger at
+// debug level, with the name of the field and the catalog code of the error.
+// The error message is included too, except for fields holding sensitive
+// values (Secret, Password, CardNumber, tokens, and any parser wrapped by
+// Sensitive), whose errors may contain the submitted value.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// Rest causes every field of the form which is not read by a parser of the
+// schema to be stored in rest, e.g. to pass the extra fields on to another
+// service. Rest replaces the content of rest on each parse.
+func Rest(rest *url.Values) Option {
+	return func(o *options) {
+		o.rest = rest
+	}
+}
+
+// Audit causes an AuditRecord of each parse to be stored in record, replacing
+// its previous content. The values of sensitive fields are redacted, so the
+// record is safe to persist.
+func Audit(record *AuditRecord) Option {
+	return func(o *options) {
+		o.audit = record
+	}
+}
+
+// CollectWarnings causes every Warning produced while parsing to be appended to
+// warnings. Warnings do not cause parsing to fail, and are discarded unless
+// this option is used.
+func CollectWarnings(warnings *[]Warning) Option {
+	return func(o *options) {
+		o.warnings = warnings
+	}
+}
+
+// StreamFiles causes the files of a multipart form to be given to fn as they
+// are read from the request body, rather than being buffered in memory or
+// temporary files. The name given to fn is the name of the form field of the
+// file. Files are not available to the File parser when this option is used.
+// If fn returns an error, parsing stops and the error is returned.
+func StreamFiles(fn func(name string, part io.Reader) error) Option {
+	return func(o *options) {
+		o.stream = fn
+	}
+}
+
+// validText reports whether s is valid UTF-8 free of control characters other
+// than tab, line feed, and carriage return.
+func validText(s string) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r == '\t', r == '\n', r == '\r':
+			continue
+		case unicode.IsControl(r):
+			return false
+		}
+	}
+	return true
+}
+
+func invalidText(s string) bool {
+	return !validText(s)
+}
+
+// trimValues returns a copy of data with white space trimmed from each value.
+func trimValues(data url.Values) url.Values {
+	trimmed := make(url.Values, len(data))
+	for name, values := range data {
+		cleaned := make([]string, len(values))
+		for i, value := range values {
+			cleaned[i] = strings.TrimSpace(value)
+		}
+		trimmed[name] = cleaned
+	}
+	return trimmed
+}
@@ -0,0 +1,465 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"errors"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_Strict(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"name":       []string{"bob"},
+		"meta.color": []string{"red"},
+		"age_min":    []string{"1"},
+		"age_max":    []string{"2"},
+	}
+
+	var (
+		name   string
+		meta   map[string]string
+		lo, hi int
+	)
+
+	err := ParseValues(data, Schema{
+		"name": String(&name),
+		"meta": Map(&meta),
+		"age":  IntRangePair(&lo, &hi),
+	}, Strict())
+	must.NoError(t, err)
+
+	data.Set("extra", "x")
+	err = ParseValues(data, Schema{
+		"name": String(&name),
+		"meta": Map(&meta),
+		"age":  IntRangePair(&lo, &hi),
+	}, Strict())
+	must.ErrorIs(t, err, ErrUnexpectedField)
+
+	var fe *FieldError
+	must.True(t, errors.As(err, &fe))
+	must.Eq(t, "extra", fe.Field)
+}
+
+func Test_Parse_CollectAllErrors(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"two": []string{"not a number"},
+	}
+
+	var (
+		one string
+		two int
+	)
+
+	err := ParseValues(data, Schema{
+		"one": String(&one),
+		"two": Int(&two),
+	}, CollectAllErrors())
+	must.Error(t, err)
+
+	var errs Errors
+	must.True(t, errors.As(err, &errs))
+	must.SliceLen(t, 2, errs)
+	must.ErrorIs(t, err, ErrNoValue)
+}
+
+func Test_Parse_CollectAllErrors_ordered(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"zz":    {"unexpected"},
+		"b":     {"ten"},
+		"aa":    {"unexpected"},
+		"m\x00": {"unexpected"},
+	}
+
+	var a string
+	var b int
+	for range 20 {
+		err := ParseValues(data, Schema{
+			"a": String(&a),
+			"b": Int(&b),
+		}, Strict(), StrictText(), CollectAllErrors())
+
+		var errs Errors
+		must.True(t, errors.As(err, &errs))
+		fields := make([]string, len(errs))
+		for i, fe := range errs {
+			fields[i] = fe.Field
+		}
+		must.Eq(t, []string{"a", "aa", "b", "m\x00", "m\x00", "zz"}, fields)
+	}
+}
+
+func Test_Parse_CollectAllErrors_missing(t *testing.T) {
+	t.Parallel()
+
+	var a, b, c string
+	var d int
+	err := ParseValues(url.Values{"d": {"x"}}, Schema{
+		"c": String(&c),
+		"a": String(&a),
+		"b": String(&b),
+		"d": Int(&d),
+	}, CollectAllErrors())
+
+	var errs Errors
+	must.True(t, errors.As(err, &errs))
+	must.SliceLen(t, 4, errs)
+	must.Eq(t, []string{"a", "b", "c"}, errs.Missing())
+	must.StrHasPrefix(t, "could not parse value: missing: a, b, c; a: expected value to exist; ", err.Error())
+
+	err = ParseValues(url.Values{"a": {"x"}, "b": {"y"}}, Schema{
+		"a": String(&a),
+		"b": String(&b),
+		"c": String(&c),
+	}, CollectAllErrors())
+	must.EqError(t, err, "could not parse value: c: expected value to exist")
+}
+
+func Test_Parse_missing(t *testing.T) {
+	t.Parallel()
+
+	var a, b, c string
+	var d int
+	schema := Schema{
+		"c": String(&c),
+		"a": String(&a),
+		"b": String(&b),
+		"d": Int(&d),
+	}
+
+	// every missing field is named, even without CollectAllErrors
+	err := ParseValues(url.Values{"d": {"4"}}, schema)
+	var errs Errors
+	must.True(t, errors.As(err, &errs))
+	must.Eq(t, []string{"a", "b", "c"}, errs.Missing())
+	must.SliceLen(t, 3, errs)
+	must.StrHasPrefix(t, "could not parse value: missing: a, b, c; ", err.Error())
+
+	// the first invalid field is returned along with the missing fields,
+	// whether it is ordered before or after them
+	for _, name := range []string{"age", "zage"} {
+		var age int
+		var other string
+		err = ParseValues(url.Values{name: {"old"}}, Schema{
+			name:   Int(&age),
+			"name": String(&other),
+			"zip":  String(&other),
+		})
+		must.True(t, errors.As(err, &errs), must.Sprint(name))
+		must.SliceLen(t, 3, errs, must.Sprint(name))
+		must.Eq(t, []string{"name", "zip"}, errs.Missing())
+		must.ErrorIs(t, err, strconv.ErrSyntax)
+		must.StrContains(t, err.Error(), name+": strconv.Atoi")
+	}
+
+	// later invalid fields are not reported
+	err = ParseValues(url.Values{"a": {"x"}, "b": {"y"}}, Schema{
+		"a": Int(&d),
+		"b": Int(&d),
+		"c": String(&c),
+	})
+	must.True(t, errors.As(err, &errs))
+	must.SliceLen(t, 2, errs)
+	must.Eq(t, "a", errs[0].Field)
+	must.Eq(t, "c", errs[1].Field)
+
+	// an invalid field is returned alone when nothing is missing
+	var fe *FieldError
+	err = ParseValues(url.Values{"a": {"x"}, "b": {"y"}}, Schema{"a": Int(&d), "b": String(&b)})
+	must.True(t, errors.As(err, &fe))
+	must.Eq(t, "a", fe.Field)
+	must.True(t, errors.As(err, &errs))
+	must.SliceLen(t, 1, errs)
+
+	// a single missing field is returned as before
+	err = ParseValues(url.Values{"a": {"x"}}, Schema{"a": String(&a), "b": String(&b)})
+	must.EqError(t, err, "could not parse value: b: expected value to exist")
+}
+
+func Test_Parse_default_FieldError(t *testing.T) {
+	t.Parallel()
+
+	var one string
+	err := ParseValues(url.Values{}, Schema{
+		"one": String(&one),
+	})
+	must.EqError(t, err, "could not parse value: one: expected value to exist")
+
+	var fe *FieldError
+	must.True(t, errors.As(err, &fe))
+	must.Eq(t, "one", fe.Field)
+}
+
+func Test_Parse_MaxFields(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"a": []string{"1"},
+		"b": []string{"2"},
+		"c": []string{"3"},
+	}
+
+	err := ParseValues(data, Schema{}, MaxFields(3))
+	must.NoError(t, err)
+
+	err = ParseValues(data, Schema{}, MaxFields(2))
+	must.ErrorIs(t, err, ErrTooManyFields)
+}
+
+func Test_Parse_TrimAll(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"name": []string{"  bob \n"},
+		"age":  []string{" 42 "},
+	}
+
+	var (
+		name string
+		age  int
+	)
+
+	err := ParseValues(data, Schema{
+		"name": String(&name),
+		"age":  Int(&age),
+	}, TrimAll())
+	must.NoError(t, err)
+	must.Eq(t, "bob", name)
+	must.Eq(t, 42, age)
+	must.Eq(t, "  bob \n", data.Get("name"))
+}
+
+func Test_Parse_MaxBytes(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{"name": {strings.Repeat("x", 100)}}
+
+	var name string
+	err := Parse(postForm(t, data), Schema{"name": String(&name)}, MaxBytes(1024))
+	must.NoError(t, err)
+	must.Eq(t, 100, len(name))
+
+	err = Parse(postForm(t, data), Schema{"name": String(&name)}, MaxBytes(64))
+	must.ErrorIs(t, err, ErrBodyTooLarge)
+}
+
+func Test_Parse_StrictText(t *testing.T) {
+	t.Parallel()
+
+	var note string
+
+	err := ParseValues(url.Values{"note": {"line one\r\n\tline two ✓"}}, Schema{
+		"note": String(&note),
+	}, StrictText())
+	must.NoError(t, err)
+
+	for _, value := range []string{"nul\x00byte", "bell\a", "bad\xffutf8", "del\x7f"} {
+		note = ""
+		err = ParseValues(url.Values{"note": {value}}, Schema{
+			"note": String(&note),
+		}, StrictText())
+		must.ErrorIs(t, err, ErrInvalidText, must.Sprint(value))
+		must.Eq(t, "", note)
+	}
+
+	err = ParseValues(url.Values{"bad\x00key": {"x"}}, Schema{}, StrictText())
+	must.ErrorIs(t, err, ErrInvalidText)
+}
+
+func Test_Parse_WithObserver(t *testing.T) {
+	t.Parallel()
+
+	observed := make(map[string]error)
+	observer := func(field string, err error, dur time.Duration) {
+		must.True(t, dur >= 0)
+		observed[field] = err
+	}
+
+	var (
+		name string
+		age  int
+	)
+
+	err := ParseValues(url.Values{"name": {"bob"}, "age": {"x"}}, Schema{
+		"name": String(&name),
+		"age":  Int(&age),
+	}, WithObserver(observer), CollectAllErrors())
+	must.Error(t, err)
+	must.MapLen(t, 2, observed)
+	must.NoError(t, observed["name"])
+	must.Error(t, observed["age"])
+}
+
+func Test_Parse_WithLogger(t *testing.T) {
+	t.Parallel()
+
+	var sb strings.Builder
+	logger := slog.New(slog.NewTextHandler(&sb, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	var (
+		age int
+		pin int
+	)
+
+	err := ParseValues(url.Values{"age": {"old"}, "pin": {"12x4"}}, Schema{
+		"age": Int(&age),
+		"pin": Sensitive(Int(&pin)),
+	}, WithLogger(logger), CollectAllErrors())
+	must.Error(t, err)
+
+	output := sb.String()
+	must.StrContains(t, output, `level=DEBUG msg="form field failed to parse" field=age code=parse_failure error=`)
+	must.StrContains(t, output, `\"old\"`)
+	must.StrContains(t, output, "field=pin code=parse_failure\n")
+	must.StrNotContains(t, output, "12x4")
+}
+
+func Test_Parse_Rest(t *testing.T) {
+	t.Parallel()
+
+	var (
+		name  string
+		meta  map[string]string
+		extra url.Values
+	)
+	err := ParseValues(url.Values{
+		"name":       {"bob"},
+		"meta.k":     {"v"},
+		"utm_source": {"newsletter"},
+		"color":      {"red", "blue"},
+	}, Schema{
+		"name": String(&name),
+		"meta": Map(&meta),
+	}, Rest(&extra))
+	must.NoError(t, err)
+	must.Eq(t, "bob", name)
+	must.Eq(t, url.Values{
+		"utm_source": {"newsletter"},
+		"color":      {"red", "blue"},
+	}, extra)
+
+	err = ParseValues(url.Values{"name": {"alice"}}, Schema{
+		"name": String(&name),
+	}, Rest(&extra))
+	must.NoError(t, err)
+	must.MapEmpty(t, extra)
+}
+
+func Test_Parse_Rest_ParseBody(t *testing.T) {
+	t.Parallel()
+
+	var (
+		name  string
+		meta  map[string]string
+		extra url.Values
+	)
+	compiled, err := Compile(Schema{
+		"name": String(&name),
+		"meta": Map(&meta),
+	})
+	must.NoError(t, err)
+
+	err = compiled.ParseBody(postForm(t, url.Values{
+		"name":       {"bob"},
+		"meta.k":     {"v"},
+		"utm_source": {"newsletter"},
+		"color":      {"red", "blue"},
+	}), Rest(&extra))
+	must.NoError(t, err)
+	must.Eq(t, "bob", name)
+	must.Eq(t, url.Values{
+		"utm_source": {"newsletter"},
+		"color":      {"red", "blue"},
+	}, extra)
+
+	err = compiled.ParseBody(postForm(t, url.Values{"name": {"alice"}, "meta.k": {"v"}}), Rest(&extra))
+	must.NoError(t, err)
+	must.MapEmpty(t, extra)
+}
+
+func Test_Parse_MaxValueLen(t *testing.T) {
+	t.Parallel()
+
+	var name, bio string
+	schema := Schema{
+		"name": String(&name),
+		"bio":  StringOr(&bio, ""),
+	}
+
+	err := ParseValues(url.Values{"name": {"bob"}, "bio": {"short"}}, schema, MaxValueLen(5))
+	must.NoError(t, err)
+
+	err = ParseValues(url.Values{
+		"name":  {"bob"},
+		"bio":   {strings.Repeat("x", 6)},
+		"extra": {"ok", strings.Repeat("y", 1<<20)},
+	}, schema, MaxValueLen(5), CollectAllErrors())
+	must.ErrorIs(t, err, ErrValueTooLong)
+	must.EqError(t, err, "could not parse value: bio: value exceeds length limit: limit is 5 bytes")
+	must.Eq(t, "short", bio) // unchanged, since no field was parsed
+}
+
+func Test_Parse_Wire(t *testing.T) {
+	t.Parallel()
+
+	var email, name string
+	var address map[string]string
+	schema := Schema{
+		"email":   String(&email),
+		"name":    StringOr(&name, "anonymous"),
+		"address": Map(&address),
+	}
+
+	err := ParseValues(url.Values{
+		"userEmail":         {"bob@example.com"},
+		"userAddress[city]": {"Austin"},
+	}, schema, Wire("userEmail", "email"), Wire("userAddress", "address"), Strict())
+	must.NoError(t, err)
+	must.Eq(t, "bob@example.com", email)
+	must.Eq(t, map[string]string{"city": "Austin"}, address)
+
+	err = ParseValues(url.Values{
+		"userEmail": {"bob@example.com"},
+		"email":     {"alice@example.com"},
+		"address.x": {"y"},
+	}, schema, Wire("userEmail", "email"))
+	must.ErrorIs(t, err, ErrMulitpleValues)
+
+	err = ParseValues(url.Values{"address.x": {"y"}}, schema, Wire("userEmail", "email"))
+	must.EqError(t, err, "could not parse value: email: expected value to exist")
+}
+
+func Test_Parse_Wire_ParseBody(t *testing.T) {
+	t.Parallel()
+
+	var email string
+	var address map[string]string
+	compiled, err := Compile(Schema{
+		"email":   String(&email),
+		"address": Map(&address),
+	})
+	must.NoError(t, err)
+
+	err = compiled.ParseBody(postForm(t, url.Values{
+		"userEmail":         {"bob@example.com"},
+		"userAddress[city]": {"Austin"},
+		"ignored":           {"1"},
+	}), Wire("userEmail", "email"), Wire("userAddress", "address"))
+	must.NoError(t, err)
+	must.Eq(t, "bob@example.com", email)
+	must.Eq(t, map[string]string{"city": "Austin"}, address)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shoenig/go-conceal"
+)
+
+// A PasswordPolicy describes the requirements a password must satisfy.
+type PasswordPolicy struct {
+	// MinLength is the minimum number of characters in the password.
+	MinLength int
+
+	// RequireUpper requires at least one upper case letter.
+	RequireUpper bool
+
+	// RequireLower requires at least one lower case letter.
+	RequireLower bool
+
+	// RequireDigit requires at least one digit.
+	RequireDigit bool
+
+	// RequireSymbol requires at least one character which is not a letter,
+	// digit, or space.
+	RequireSymbol bool
+
+	// Deny is an optional hook for rejecting passwords by other means, e.g. by
+	// checking a breached password database. A non-nil error rejects the
+	// password; the error must not contain the password itself.
+	Deny func(password string) error
+}
+
+// violations returns a description of each requirement of the policy which
+// password does not satisfy.
+func (pp PasswordPolicy) violations(password string) []string {
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsLetter(r) && !unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+
+	var reasons []string
+	if utf8.RuneCountInString(password) < pp.MinLength {
+		reasons = append(reasons, fmt.Sprintf("at least %d characters", pp.MinLength))
+	}
+	if pp.RequireUpper && !upper {
+		reasons = append(reasons, "an upper case letter")
+	}
+	if pp.RequireLower && !lower {
+		reasons = append(reasons, "a lower case letter")
+	}
+	if pp.RequireDigit && !digit {
+		reasons = append(reasons, "a digit")
+	}
+	if pp.RequireSymbol && !symbol {
+		reasons = append(reasons, "a symbol")
+	}
+	return reasons
+}
+
+type passwordParser struct {
+	policy      PasswordPolicy
+	destination **conceal.Text
+}
+
+func (p *passwordParser) describe() field {
+	var constraints []string
+	if reasons := p.policy.violations(""); len(reasons) > 0 {
+		constraints = append(constraints, "requires "+strings.Join(reasons, ", "))
+	}
+	return field{kind: "password", required: true, sensitive: true, constraints: constraints}
+}
+
+func (p *passwordParser) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *passwordParser) Parse(values []string) error {
+	if err := p.dryRun(values); err != nil {
+		return err
+	}
+
+	password := values[0]
+	if p.policy.Deny != nil {
+		if err := p.policy.Deny(password); err != nil {
+			return fmt.Errorf("%w: %w", ErrPasswordPolicy, err)
+		}
+	}
+
+	*p.destination = conceal.New(password)
+	return nil
+}
+
+// dryRun checks values against the policy, except for the Deny hook which may
+// have side effects such as calling an external service.
+func (p *passwordParser) dryRun(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0:
+		return ErrNoValue
+	}
+
+	if reasons := p.policy.violations(values[0]); len(reasons) > 0 {
+		return fmt.Errorf("%w: requires %s", ErrPasswordPolicy, strings.Join(reasons, ", "))
+	}
+	return nil
+}
+
+// Password is used to extract a form data value into a Go conceal.Text, after
+// checking that the value satisfies the given policy. If the value violates
+// the policy or is missing then an error is returned during parsing; the error
+// describes which requirements were not met, but never contains the password.
+func Password(s **conceal.Text, policy PasswordPolicy) Parser {
+	return &passwordParser{
+		policy:      policy,
+		destination: s,
+	}
+}
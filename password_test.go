@@ -0,0 +1,78 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/go-conceal"
+	"github.com/shoenig/test/must"
+)
+
+var testPolicy = PasswordPolicy{
+	MinLength:     10,
+	RequireUpper:  true,
+	RequireLower:  true,
+	RequireDigit:  true,
+	RequireSymbol: true,
+}
+
+func Test_Parse_Password(t *testing.T) {
+	t.Parallel()
+
+	var password *conceal.Text
+	err := ParseValues(url.Values{"password": {"Correct-Horse-9"}}, Schema{
+		"password": Password(&password, testPolicy),
+	})
+	must.NoError(t, err)
+	must.Eq(t, "Correct-Horse-9", password.Unveil())
+}
+
+func Test_Parse_Password_violations(t *testing.T) {
+	t.Parallel()
+
+	var password *conceal.Text
+	err := ParseValues(url.Values{"password": {"hunter"}}, Schema{
+		"password": Password(&password, testPolicy),
+	})
+	must.ErrorIs(t, err, ErrPasswordPolicy)
+	must.StrContains(t, err.Error(), "at least 10 characters, an upper case letter, a digit, a symbol")
+	must.StrNotContains(t, err.Error(), "hunter")
+	must.Nil(t, password)
+}
+
+func Test_Parse_Password_deny(t *testing.T) {
+	t.Parallel()
+
+	breached := errors.New("password appears in a breach")
+	policy := PasswordPolicy{
+		MinLength: 4,
+		Deny: func(password string) error {
+			if password == "password1" {
+				return breached
+			}
+			return nil
+		},
+	}
+
+	var password *conceal.Text
+	err := ParseValues(url.Values{"password": {"password1"}}, Schema{
+		"password": Password(&password, policy),
+	})
+	must.ErrorIs(t, err, ErrPasswordPolicy)
+	must.ErrorIs(t, err, breached)
+	must.Nil(t, password)
+}
+
+func Test_Parse_Password_missing(t *testing.T) {
+	t.Parallel()
+
+	var password *conceal.Text
+	err := ParseValues(url.Values{}, Schema{
+		"password": Password(&password, testPolicy),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
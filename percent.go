@@ -0,0 +1,103 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"strconv"
+	"strings"
+)
+
+// A PercentScale determines how a Percent parser interprets form values which
+// are submitted without a trailing percent sign.
+type PercentScale int
+
+const (
+	// PercentPoints interprets values on a scale of 0 to 100, e.g. "45" is
+	// parsed as 0.45.
+	PercentPoints PercentScale = iota
+
+	// PercentFraction interprets values on a scale of 0 to 1, e.g. "0.45" is
+	// parsed as 0.45.
+	PercentFraction
+)
+
+type percentParser struct {
+	required    bool
+	scale       PercentScale
+	alt         float64
+	destination *float64
+}
+
+func (p *percentParser) describe() field {
+	return field{
+		kind:        "percent",
+		required:    p.required,
+		alt:         p.alt,
+		constraints: []string{"between 0% and 100%"},
+	}
+}
+
+func (p *percentParser) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *percentParser) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	s := strings.TrimSpace(values[0])
+	divisor := 1.0
+	if trimmed, ok := strings.CutSuffix(s, "%"); ok {
+		s, divisor = strings.TrimSpace(trimmed), 100
+	} else if p.scale == PercentPoints {
+		divisor = 100
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+
+	// written in the negative so that NaN is also rejected
+	f /= divisor
+	if !(f >= 0 && f <= 1) {
+		return ErrOutOfRange
+	}
+
+	*p.destination = f
+	return nil
+}
+
+// Percent is used to extract a form data value describing a percentage into a
+// Go float64, normalized to a fraction between 0 and 1. Values with a trailing
+// percent sign (e.g. "45%") are always on a scale of 0 to 100; otherwise the
+// given scale determines how the value is interpreted. If the value is not a
+// number, is out of range, or is missing then an error is returned during
+// parsing.
+func Percent(f *float64, scale PercentScale) Parser {
+	return &percentParser{
+		required:    true,
+		scale:       scale,
+		destination: f,
+	}
+}
+
+// PercentOr is used to extract a form data value describing a percentage into
+// a Go float64, normalized to a fraction between 0 and 1. If the value is
+// missing, then the alt value is used instead.
+func PercentOr(f *float64, scale PercentScale, alt float64) Parser {
+	*f = alt
+	return &percentParser{
+		required:    false,
+		scale:       scale,
+		alt:         alt,
+		destination: f,
+	}
+}
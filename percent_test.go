@@ -0,0 +1,68 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_Percent(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"points":          []string{"45"},
+		"points_sign":     []string{"45%"},
+		"fraction":        []string{"0.45"},
+		"fraction_sign":   []string{"45 %"},
+		"fraction_bounds": []string{"1"},
+	}
+
+	var a, b, c, d, e, f float64
+	err := ParseValues(data, Schema{
+		"points":          Percent(&a, PercentPoints),
+		"points_sign":     Percent(&b, PercentPoints),
+		"fraction":        Percent(&c, PercentFraction),
+		"fraction_sign":   Percent(&d, PercentFraction),
+		"fraction_bounds": Percent(&e, PercentFraction),
+		"missing":         PercentOr(&f, PercentPoints, 0.1),
+	})
+	must.NoError(t, err)
+	must.Eq(t, 0.45, a)
+	must.Eq(t, 0.45, b)
+	must.Eq(t, 0.45, c)
+	must.Eq(t, 0.45, d)
+	must.Eq(t, 1.0, e)
+	must.Eq(t, 0.1, f)
+}
+
+func Test_Parse_Percent_out_of_range(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"101", "-1", "150%", "NaN"} {
+		var f float64
+		err := ParseValues(url.Values{"discount": {value}}, Schema{
+			"discount": Percent(&f, PercentPoints),
+		})
+		must.ErrorIs(t, err, ErrOutOfRange, must.Sprint(value))
+	}
+
+	var f float64
+	err := ParseValues(url.Values{"discount": {"45"}}, Schema{
+		"discount": Percent(&f, PercentFraction),
+	})
+	must.ErrorIs(t, err, ErrOutOfRange)
+}
+
+func Test_Parse_Percent_malformed(t *testing.T) {
+	t.Parallel()
+
+	var f float64
+	err := ParseValues(url.Values{"discount": {"lots"}}, Schema{
+		"discount": Percent(&f, PercentPoints),
+	})
+	must.Error(t, err)
+}
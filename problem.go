@@ -0,0 +1,78 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// fieldErrors returns every FieldError contained in err.
+func fieldErrors(err error) []*FieldError {
+	var errs Errors
+	if errors.As(err, &errs) {
+		return errs
+	}
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		return []*FieldError{fe}
+	}
+	return nil
+}
+
+// messages maps the name of each field in errs to the message of its first
+// error.
+func messages(errs []*FieldError) map[string]string {
+	m := make(map[string]string, len(errs))
+	for _, fe := range errs {
+		if _, exists := m[fe.Field]; !exists {
+			m[fe.Field] = fe.Err.Error()
+		}
+	}
+	return m
+}
+
+// MarshalJSON implements json.Marshaler, encoding e as an object of the form
+// {"errors":{"field":"message"}}. Where a field failed more than once, only
+// its first error is included. Since the failure of a field is always
+// returned as an Errors, the error of a parse may be encoded directly.
+func (e Errors) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Errors map[string]string `json:"errors"`
+	}{
+		Errors: messages(e),
+	})
+}
+
+// A Problem is a problem details object as described by RFC 7807, suitable
+// for encoding as the JSON body of an API response. The Errors member is an
+// extension mapping the name of each field which failed to its message.
+type Problem struct {
+	Type   string            `json:"type"`
+	Title  string            `json:"title"`
+	Status int               `json:"status"`
+	Detail string            `json:"detail,omitempty"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// ProblemDetails describes err, as returned by parsing a form, as a Problem.
+// The status is 413 if the request body was too large, and 422 otherwise.
+func ProblemDetails(err error) *Problem {
+	status := http.StatusUnprocessableEntity
+	if errors.Is(err, ErrBodyTooLarge) {
+		status = http.StatusRequestEntityTooLarge
+	}
+
+	p := &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: err.Error(),
+	}
+	if errs := fieldErrors(err); len(errs) > 0 {
+		p.Errors = messages(errs)
+	}
+	return p
+}
@@ -0,0 +1,75 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Errors_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var (
+		name string
+		age  int
+	)
+	err := ParseValues(url.Values{"age": {"old"}}, Schema{
+		"name": String(&name),
+		"age":  Int(&age),
+	}, CollectAllErrors())
+	must.Error(t, err)
+
+	b, err := json.Marshal(err)
+	must.NoError(t, err)
+	must.Eq(t, `{"errors":{"age":"strconv.Atoi: parsing \"old\": invalid syntax","name":"expected value to exist"}}`, string(b))
+}
+
+func Test_Errors_MarshalJSON_first(t *testing.T) {
+	t.Parallel()
+
+	var age int
+	err := ParseValues(url.Values{"age": {"old"}}, Schema{
+		"age": Int(&age),
+	})
+	must.EqError(t, err, `could not parse value: age: strconv.Atoi: parsing "old": invalid syntax`)
+
+	b, err := json.Marshal(err)
+	must.NoError(t, err)
+	must.Eq(t, `{"errors":{"age":"strconv.Atoi: parsing \"old\": invalid syntax"}}`, string(b))
+}
+
+func Test_ProblemDetails(t *testing.T) {
+	t.Parallel()
+
+	var name string
+	err := ParseValues(url.Values{}, Schema{
+		"name": String(&name),
+	})
+
+	p := ProblemDetails(err)
+	must.Eq(t, &Problem{
+		Type:   "about:blank",
+		Title:  "Unprocessable Entity",
+		Status: http.StatusUnprocessableEntity,
+		Detail: "could not parse value: name: expected value to exist",
+		Errors: map[string]string{"name": "expected value to exist"},
+	}, p)
+
+	b, err := json.Marshal(p)
+	must.NoError(t, err)
+	must.StrContains(t, string(b), `"errors":{"name":"expected value to exist"}`)
+}
+
+func Test_ProblemDetails_body_too_large(t *testing.T) {
+	t.Parallel()
+
+	p := ProblemDetails(ErrBodyTooLarge)
+	must.Eq(t, http.StatusRequestEntityTooLarge, p.Status)
+	must.Nil(t, p.Errors)
+}
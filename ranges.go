@@ -0,0 +1,340 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type intRangeParser[T IntType] struct {
+	required     bool
+	altLo, altHi T
+	lo, hi       *T
+}
+
+func (p *intRangeParser[T]) describe() field {
+	f := field{
+		kind:     "int range",
+		required: p.required,
+		constraints: []string{
+			"submitted as name (lo-hi) or name_min and name_max",
+			"lo <= hi",
+		},
+	}
+	if !p.required {
+		f.alt = fmt.Sprintf("%d-%d", p.altLo, p.altHi)
+	}
+	return f
+}
+
+func (p *intRangeParser[T]) destinations() []any {
+	return []any{p.lo, p.hi}
+}
+
+// Parse implements Parser, extracting a range written in the form "lo-hi".
+func (p *intRangeParser[T]) Parse(values []string) error {
+	return p.parseForm(context.Background(), "", url.Values{"": values}, new(options))
+}
+
+func (p *intRangeParser[T]) parseForm(_ context.Context, name string, data url.Values, _ *options) error {
+	combined := data[name]
+	switch {
+	case len(combined) > 1:
+		return ErrMulitpleValues
+	case len(combined) == 1:
+		return p.combined(combined[0])
+	}
+
+	lows, highs := data[name+"_min"], data[name+"_max"]
+	switch {
+	case len(lows) > 1 || len(highs) > 1:
+		return ErrMulitpleValues
+	case (len(lows) == 0 || len(highs) == 0) && p.required:
+		return ErrNoValue
+	}
+
+	lo, hi := *p.lo, *p.hi
+	if len(lows) == 1 {
+		bound, err := p.bound(lows[0])
+		if err != nil {
+			return err
+		}
+		lo = bound
+	}
+	if len(highs) == 1 {
+		bound, err := p.bound(highs[0])
+		if err != nil {
+			return err
+		}
+		hi = bound
+	}
+	return p.set(lo, hi)
+}
+
+func (p *intRangeParser[T]) claims(name, key string) bool {
+	return key == name || key == name+"_min" || key == name+"_max"
+}
+
+func (p *intRangeParser[T]) combined(value string) error {
+	// skip the first character so a negative lower bound is not mistaken
+	// for the separator
+	i := strings.IndexByte(value[min(1, len(value)):], '-') + 1
+	if i < 1 {
+		return ErrInvalidRange
+	}
+
+	lo, err := p.bound(value[:i])
+	if err != nil {
+		return err
+	}
+
+	hi, err := p.bound(value[i+1:])
+	if err != nil {
+		return err
+	}
+
+	return p.set(lo, hi)
+}
+
+// bound parses one bound of the range, which must fit in T.
+func (p *intRangeParser[T]) bound(value string) (T, error) {
+	bound, err := parseID[T](value)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, fmt.Errorf("%w: %s", ErrOutOfRange, value)
+	}
+	return bound, err
+}
+
+func (p *intRangeParser[T]) set(lo, hi T) error {
+	if lo > hi {
+		return ErrInvalidRange
+	}
+	*p.lo, *p.hi = lo, hi
+	return nil
+}
+
+// IntRangePair is used to extract a range of integers into a pair of Go ints.
+// The range may be submitted as a single form value like "10-50", or as a
+// pair of form values named with "_min" and "_max" suffixes of the schema
+// field name. If the lower bound exceeds the upper bound, or either bound
+// is missing, then an error is returned during parsing.
+func IntRangePair[T IntType](lo, hi *T) Parser {
+	return &intRangeParser[T]{
+		required: true,
+		lo:       lo,
+		hi:       hi,
+	}
+}
+
+// IntRangePairOr is used to extract a range of integers into a pair of Go
+// ints. If either bound is missing, then the corresponding alt value is used
+// instead.
+func IntRangePairOr[T IntType](lo, hi *T, altLo, altHi T) Parser {
+	*lo, *hi = altLo, altHi
+	return &intRangeParser[T]{
+		required: false,
+		altLo:    altLo,
+		altHi:    altHi,
+		lo:       lo,
+		hi:       hi,
+	}
+}
+
+type timeRangeParser struct {
+	layout     string
+	maxSpan    time.Duration
+	start, end *time.Time
+}
+
+func (p *timeRangeParser) describe() field {
+	f := field{
+		kind:     "time range",
+		required: true,
+		constraints: []string{
+			"submitted as name (start/end) or name_start and name_end",
+			"layout " + p.layout,
+			"start <= end",
+		},
+	}
+	if p.maxSpan > 0 {
+		f.constraints = append(f.constraints, "span <= "+p.maxSpan.String())
+	}
+	return f
+}
+
+func (p *timeRangeParser) destinations() []any {
+	return []any{p.start, p.end}
+}
+
+// Parse implements Parser, extracting a range written in the form "start/end".
+func (p *timeRangeParser) Parse(values []string) error {
+	return p.parseForm(context.Background(), "", url.Values{"": values}, new(options))
+}
+
+func (p *timeRangeParser) parseForm(_ context.Context, name string, data url.Values, _ *options) error {
+	combined := data[name]
+	switch {
+	case len(combined) > 1:
+		return ErrMulitpleValues
+	case len(combined) == 1:
+		return p.combined(combined[0])
+	}
+
+	starts, ends := data[name+"_start"], data[name+"_end"]
+	switch {
+	case len(starts) > 1 || len(ends) > 1:
+		return ErrMulitpleValues
+	case len(starts) == 0 || len(ends) == 0:
+		return ErrNoValue
+	}
+	return p.parse(starts[0], ends[0])
+}
+
+func (p *timeRangeParser) claims(name, key string) bool {
+	return key == name || key == name+"_start" || key == name+"_end"
+}
+
+func (p *timeRangeParser) combined(value string) error {
+	// the separator is the first slash which is not part of the layout
+	i, skip := -1, strings.Count(p.layout, "/")
+	for range skip + 1 {
+		j := strings.IndexByte(value[i+1:], '/')
+		if j < 0 {
+			return ErrInvalidRange
+		}
+		i += j + 1
+	}
+	return p.parse(value[:i], value[i+1:])
+}
+
+func (p *timeRangeParser) parse(startValue, endValue string) error {
+	start, err := time.Parse(p.layout, strings.TrimSpace(startValue))
+	if err != nil {
+		return err
+	}
+
+	end, err := time.Parse(p.layout, strings.TrimSpace(endValue))
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case start.After(end):
+		return ErrInvalidRange
+	case p.maxSpan > 0 && end.Sub(start) > p.maxSpan:
+		return fmt.Errorf("%w: must span at most %s", ErrOutOfRange, p.maxSpan)
+	}
+
+	*p.start, *p.end = start, end
+	return nil
+}
+
+// TimeRange is used to extract a range of times into a pair of Go time.Time
+// values, each formatted with layout as understood by time.Parse. The range
+// may be submitted as a single form value like "2024-01-02/2024-01-09", or as
+// a pair of form values named with "_start" and "_end" suffixes of the schema
+// field name. If the start is after the end, or either is missing, then an
+// error is returned during parsing.
+func TimeRange(start, end *time.Time, layout string) Parser {
+	return &timeRangeParser{
+		layout: layout,
+		start:  start,
+		end:    end,
+	}
+}
+
+// TimeRangeWithin is like TimeRange, but also returns an error during parsing
+// if the end is more than maxSpan after the start, e.g. to limit the period
+// of a report.
+func TimeRangeWithin(start, end *time.Time, layout string, maxSpan time.Duration) Parser {
+	return &timeRangeParser{
+		layout:  layout,
+		maxSpan: maxSpan,
+		start:   start,
+		end:     end,
+	}
+}
+
+type boundedIntParser[T IntType] struct {
+	lo, hi      T
+	required    bool
+	alt         T
+	destination *T
+}
+
+func (p *boundedIntParser[T]) describe() field {
+	return field{
+		kind:        "int",
+		required:    p.required,
+		alt:         p.alt,
+		constraints: []string{fmt.Sprintf("between %d and %d", p.lo, p.hi)},
+	}
+}
+
+func (p *boundedIntParser[T]) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *boundedIntParser[T]) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	// parse as T, so that neither the value nor the bounds can wrap around
+	i, err := parseID[T](values[0])
+	switch {
+	case err != nil && !integer(values[0]):
+		return err
+	case err != nil || i < p.lo || i > p.hi:
+		return fmt.Errorf("%w: must be between %d and %d", ErrOutOfRange, p.lo, p.hi)
+	}
+
+	*p.destination = i
+	return nil
+}
+
+// integer reports whether value is written as an integer, which may be too
+// large or too small for any Go integer type.
+func integer(value string) bool {
+	_, err := strconv.ParseInt(value, 10, 64)
+	return err == nil || errors.Is(err, strconv.ErrRange)
+}
+
+// IntInRange is used to extract a form data value into a Go int, which must be
+// between lo and hi inclusive, e.g. a rating from 1 to 5. If the value is not
+// an int in the range or is missing then an error is returned during parsing,
+// stating the accepted range.
+func IntInRange[T IntType](i *T, lo, hi T) Parser {
+	return &boundedIntParser[T]{
+		lo:          lo,
+		hi:          hi,
+		required:    true,
+		destination: i,
+	}
+}
+
+// IntInRangeOr is used to extract a form data value into a Go int, which must
+// be between lo and hi inclusive. If the value is missing, then the alt value
+// is used instead.
+func IntInRangeOr[T IntType](i *T, lo, hi, alt T) Parser {
+	*i = alt
+	return &boundedIntParser[T]{
+		lo:          lo,
+		hi:          hi,
+		required:    false,
+		alt:         alt,
+		destination: i,
+	}
+}
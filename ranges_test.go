@@ -0,0 +1,257 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"math"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_IntRangePair_combined(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"price": []string{"10-50"},
+		"temp":  []string{"-10--5"},
+	}
+
+	var lo, hi, cold, warm int
+	err := ParseValues(data, Schema{
+		"price": IntRangePair(&lo, &hi),
+		"temp":  IntRangePair(&cold, &warm),
+	})
+	must.NoError(t, err)
+	must.Eq(t, 10, lo)
+	must.Eq(t, 50, hi)
+	must.Eq(t, -10, cold)
+	must.Eq(t, -5, warm)
+}
+
+func Test_Parse_IntRangePair_paired(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"age_min": []string{"18"},
+		"age_max": []string{"65"},
+	}
+
+	var lo, hi int
+	err := ParseValues(data, Schema{
+		"age": IntRangePair(&lo, &hi),
+	})
+	must.NoError(t, err)
+	must.Eq(t, 18, lo)
+	must.Eq(t, 65, hi)
+}
+
+func Test_Parse_IntRangePair_inverted(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"price": []string{"50-10"},
+	}
+
+	var lo, hi int
+	err := ParseValues(data, Schema{
+		"price": IntRangePair(&lo, &hi),
+	})
+	must.ErrorIs(t, err, ErrInvalidRange)
+}
+
+func Test_Parse_IntRangePair_malformed(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"10", "10-", "a-b", "-"} {
+		data := url.Values{
+			"price": []string{value},
+		}
+
+		var lo, hi int
+		err := ParseValues(data, Schema{
+			"price": IntRangePair(&lo, &hi),
+		})
+		must.Error(t, err, must.Sprint(value))
+	}
+}
+
+func Test_Parse_IntRangePair_overflow(t *testing.T) {
+	t.Parallel()
+
+	for _, data := range []url.Values{
+		{"size": {"10-300"}},
+		{"size_min": {"10"}, "size_max": {"300"}},
+	} {
+		var lo, hi uint8
+		err := ParseValues(data, Schema{
+			"size": IntRangePair(&lo, &hi),
+		})
+		must.ErrorIs(t, err, ErrOutOfRange, must.Sprint(data))
+		must.Eq(t, 0, lo)
+		must.Eq(t, 0, hi)
+	}
+
+	var lo, hi int8
+	err := ParseValues(url.Values{"size": {"-200-10"}}, Schema{
+		"size": IntRangePair(&lo, &hi),
+	})
+	must.ErrorIs(t, err, ErrOutOfRange)
+}
+
+func Test_Parse_IntRangePair_missing(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"age_min": []string{"18"},
+	}
+
+	var lo, hi int
+	err := ParseValues(data, Schema{
+		"age": IntRangePair(&lo, &hi),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
+
+func Test_Parse_IntRangePairOr_partial(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"age_min": []string{"18"},
+	}
+
+	var lo, hi int
+	err := ParseValues(data, Schema{
+		"age":   IntRangePairOr(&lo, &hi, 0, 120),
+		"price": IntRangePairOr(new(int), new(int), 0, 100),
+	})
+	must.NoError(t, err)
+	must.Eq(t, 18, lo)
+	must.Eq(t, 120, hi)
+}
+
+func Test_Parse_TimeRange(t *testing.T) {
+	t.Parallel()
+
+	var start, end time.Time
+	schema := Schema{"period": TimeRange(&start, &end, time.DateOnly)}
+
+	err := ParseValues(url.Values{"period": {"2024-01-02/2024-01-09"}}, schema)
+	must.NoError(t, err)
+	must.Eq(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), start)
+	must.Eq(t, time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC), end)
+
+	err = ParseValues(url.Values{
+		"period_start": {"2024-03-01"},
+		"period_end":   {"2024-03-01"},
+	}, schema, Strict())
+	must.NoError(t, err)
+	must.Eq(t, start, end)
+
+	err = ParseValues(url.Values{"when": {"01/02/2024/01/09/2024"}}, Schema{
+		"when": TimeRange(&start, &end, "01/02/2006"),
+	})
+	must.NoError(t, err)
+	must.Eq(t, time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC), end)
+}
+
+func Test_Parse_TimeRange_invalid(t *testing.T) {
+	t.Parallel()
+
+	var start, end time.Time
+	schema := Schema{"period": TimeRangeWithin(&start, &end, time.DateOnly, 7*24*time.Hour)}
+
+	cases := []struct {
+		name string
+		data url.Values
+		exp  error
+	}{
+		{name: "inverted", data: url.Values{"period": {"2024-01-09/2024-01-02"}}, exp: ErrInvalidRange},
+		{name: "too long", data: url.Values{"period": {"2024-01-01/2024-02-01"}}, exp: ErrOutOfRange},
+		{name: "no separator", data: url.Values{"period": {"2024-01-01"}}, exp: ErrInvalidRange},
+		{name: "missing end", data: url.Values{"period_start": {"2024-01-01"}}, exp: ErrNoValue},
+		{name: "missing", data: url.Values{}, exp: ErrNoValue},
+		{name: "multiple", data: url.Values{"period_start": {"2024-01-01", "2024-01-02"}, "period_end": {"2024-01-03"}}, exp: ErrMulitpleValues},
+	}
+	for _, tc := range cases {
+		err := ParseValues(tc.data, schema)
+		must.ErrorIs(t, err, tc.exp, must.Sprint(tc.name))
+	}
+
+	err := ParseValues(url.Values{"period": {"yesterday/today"}}, schema)
+	must.Error(t, err)
+	must.True(t, start.IsZero())
+}
+
+func Test_Parse_IntInRange(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rating int
+		size   uint8
+	)
+	err := ParseValues(url.Values{"rating": {"5"}}, Schema{
+		"rating": IntInRange(&rating, 1, 5),
+		"size":   IntInRangeOr(&size, 10, 100, 25),
+	})
+	must.NoError(t, err)
+	must.Eq(t, 5, rating)
+	must.Eq(t, 25, size)
+}
+
+func Test_Parse_IntInRange_invalid(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rating int
+		size   uint8
+	)
+	err := ParseValues(url.Values{"rating": {"6"}}, Schema{
+		"rating": IntInRange(&rating, 1, 5),
+	})
+	must.ErrorIs(t, err, ErrOutOfRange)
+	must.StrHasSuffix(t, "must be between 1 and 5", err.Error())
+
+	// 266 would wrap around to 10 as a uint8
+	err = ParseValues(url.Values{"size": {"266"}}, Schema{
+		"size": IntInRange(&size, 10, 100),
+	})
+	must.ErrorIs(t, err, ErrOutOfRange)
+
+	err = ParseValues(url.Values{"size": {"-1"}}, Schema{
+		"size": IntInRange(&size, 10, 100),
+	})
+	must.ErrorIs(t, err, ErrOutOfRange)
+
+	err = ParseValues(url.Values{"rating": {"five"}}, Schema{
+		"rating": IntInRange(&rating, 1, 5),
+	})
+	must.ErrorIs(t, err, strconv.ErrSyntax)
+
+	err = ParseValues(url.Values{}, Schema{
+		"rating": IntInRange(&rating, 1, 5),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
+
+func Test_Parse_IntInRange_unsigned(t *testing.T) {
+	t.Parallel()
+
+	var count uint64
+	for _, value := range []string{"5", "18446744073709551615"} {
+		err := ParseValues(url.Values{"count": {value}}, Schema{
+			"count": IntInRange(&count, 1, math.MaxUint64),
+		})
+		must.NoError(t, err, must.Sprint(value))
+		must.Eq(t, value, strconv.FormatUint(count, 10))
+	}
+
+	err := ParseValues(url.Values{"count": {"18446744073709551616"}}, Schema{
+		"count": IntInRange(&count, 1, math.MaxUint64),
+	})
+	must.ErrorIs(t, err, ErrOutOfRange)
+}
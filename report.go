@@ -0,0 +1,124 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+)
+
+// A ValidationReport describes the outcome of validating a form with
+// Schema.Validate, and may be encoded as the JSON response of an endpoint
+// which validates a form as it is filled in.
+type ValidationReport struct {
+	// Valid reports whether the form would parse successfully.
+	Valid bool `json:"valid"`
+
+	// Fields reports the outcome of each field of the schema, along with
+	// any other field which failed (e.g. the fields of a Group), keyed by
+	// field name.
+	Fields map[string]FieldReport `json:"fields"`
+
+	// Error describes a failure of the form as a whole, such as having too
+	// many fields.
+	Error string `json:"error,omitempty"`
+}
+
+// A FieldReport is the outcome of validating one field of a form.
+type FieldReport struct {
+	OK    bool   `json:"ok"`
+	Code  string `json:"code,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// A dryRunner is a Parser with side effects beyond writing its destination,
+// which checks values without those effects for Schema.Validate.
+type dryRunner interface {
+	dryRun(values []string) error
+}
+
+// Validate runs every parser of s against data, as ParseValues would with the
+// CollectAllErrors option, without changing the values of the destinations of
+// s. This allows an endpoint to validate a form as it is filled in using the
+// same schema as is used to parse the submitted form.
+//
+// Parsers with side effects are checked without them: form tokens are
+// verified but not marked as used, files are not saved or copied, values are
+// not given to a SecretSink, the Deny hook of a PasswordPolicy is not called,
+// and values are not stored in the map given to Spec.Bind. Other destinations
+// are written while parsing and restored afterwards, so s must not be used
+// concurrently, and a Parser which does not report its destinations (as the
+// parsers of this package do) is not restored.
+func (s Schema) Validate(data url.Values, opts ...Option) ValidationReport {
+	restore := snapshot(s)
+	defer restore()
+
+	o := newOptions(opts)
+	o.collect = true
+	o.dryRun = true
+	err := parse(context.Background(), data, s, o)
+
+	report := ValidationReport{
+		Valid:  err == nil,
+		Fields: make(map[string]FieldReport, len(s)),
+	}
+	for name := range s {
+		report.Fields[name] = FieldReport{OK: true}
+	}
+
+	errs := fieldErrors(err)
+	for _, fe := range errs {
+		if r, exists := report.Fields[fe.Field]; exists && !r.OK {
+			continue
+		}
+		report.Fields[fe.Field] = FieldReport{
+			Code:  ErrorCode(fe.Err),
+			Error: fe.Err.Error(),
+		}
+	}
+	if err != nil && len(errs) == 0 {
+		report.Error = err.Error()
+	}
+	return report
+}
+
+// snapshot saves the values of the destinations of s, returning a function
+// which restores them. Maps are restored in place, so that a map shared with
+// the caller keeps its identity.
+func snapshot(s Schema) func() {
+	var restores []func()
+	for _, p := range s {
+		for _, d := range destinations(p) {
+			v := reflect.ValueOf(d)
+			if v.Kind() != reflect.Pointer || v.IsNil() {
+				continue
+			}
+			elem := v.Elem()
+			saved := reflect.New(elem.Type()).Elem()
+			saved.Set(elem)
+
+			if elem.Kind() == reflect.Map && !elem.IsNil() {
+				entries := reflect.MakeMapWithSize(elem.Type(), elem.Len())
+				for it := elem.MapRange(); it.Next(); {
+					entries.SetMapIndex(it.Key(), it.Value())
+				}
+				restores = append(restores, func() {
+					elem.Set(saved)
+					elem.Clear()
+					for it := entries.MapRange(); it.Next(); {
+						elem.SetMapIndex(it.Key(), it.Value())
+					}
+				})
+				continue
+			}
+			restores = append(restores, func() { elem.Set(saved) })
+		}
+	}
+	return func() {
+		for _, restore := range restores {
+			restore()
+		}
+	}
+}
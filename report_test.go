@@ -0,0 +1,167 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/shoenig/go-conceal"
+	"github.com/shoenig/test/must"
+)
+
+func Test_Schema_Validate(t *testing.T) {
+	t.Parallel()
+
+	name := "unchanged"
+	age := 7
+	tags := []string{"a"}
+	labels := map[string]string{"x": "1"}
+	schema := Schema{
+		"name":   String(&name),
+		"age":    Int(&age),
+		"email":  StringOr(new(string), "none"),
+		"tags":   Strings(&tags),
+		"labels": Map(&labels),
+	}
+
+	report := schema.Validate(url.Values{
+		"name":       {"bob"},
+		"age":        {"old"},
+		"tags":       {"b", "c"},
+		"labels[y]":  {"2"},
+		"labels[x]":  {"3"},
+		"unexpected": {"?"},
+	})
+	must.False(t, report.Valid)
+	must.Eq(t, FieldReport{OK: true}, report.Fields["name"])
+	must.Eq(t, FieldReport{OK: true}, report.Fields["email"])
+	must.False(t, report.Fields["age"].OK)
+	must.Eq(t, "parse_failure", report.Fields["age"].Code)
+	must.MapLen(t, 5, report.Fields)
+
+	must.Eq(t, "unchanged", name)
+	must.Eq(t, 7, age)
+	must.Eq(t, []string{"a"}, tags)
+	must.Eq(t, map[string]string{"x": "1"}, labels)
+
+	report = schema.Validate(url.Values{"name": {"bob"}, "age": {"30"}, "tags": {"b"}, "labels.z": {"9"}}, Strict())
+	must.True(t, report.Valid)
+	must.Eq(t, "unchanged", name)
+}
+
+func Test_Schema_Validate_errors(t *testing.T) {
+	t.Parallel()
+
+	var one, two string
+	var city string
+	report := Schema{
+		"one":  String(&one),
+		"two":  String(&two),
+		"user": Group(Schema{"city": String(&city)}),
+	}.Validate(url.Values{"two": {"x"}, "extra": {"y"}}, Strict())
+	must.False(t, report.Valid)
+	must.Eq(t, FieldReport{Code: "no_value", Error: ErrNoValue.Error()}, report.Fields["one"])
+	must.Eq(t, "no_value", report.Fields["user[city]"].Code)
+	must.Eq(t, "unexpected_field", report.Fields["extra"].Code)
+
+	report = Schema{"one": String(&one)}.Validate(url.Values{"one": {"a"}, "two": {"b"}}, MaxFields(1))
+	must.False(t, report.Valid)
+	must.True(t, report.Fields["one"].OK)
+	must.StrContains(t, report.Error, ErrTooManyFields.Error())
+
+	b, err := json.Marshal(Schema{"one": String(&one)}.Validate(url.Values{}))
+	must.NoError(t, err)
+	must.Eq(t, `{"valid":false,"fields":{"one":{"ok":false,"code":"no_value","error":"expected value to exist"}}}`, string(b))
+}
+
+// countingSink is a SecretSink counting the secrets it is given.
+type countingSink struct {
+	count *int
+}
+
+func (s countingSink) SetSecret(string) {
+	*s.count++
+}
+
+func Test_Schema_Validate_side_effects(t *testing.T) {
+	t.Parallel()
+
+	tokens := NewTokens(testTokenKey, time.Hour, NewMemoryTokenStore())
+	token, err := tokens.Generate()
+	must.NoError(t, err)
+
+	var password *conceal.Text
+	denied, secrets := 0, 0
+	schema := Schema{
+		"token": FormToken(tokens),
+		"key":   SecretTo(countingSink{count: &secrets}),
+		"password": Password(&password, PasswordPolicy{
+			MinLength: 8,
+			Deny: func(string) error {
+				denied++
+				return nil
+			},
+		}),
+	}
+	data := url.Values{
+		"token":    {token},
+		"key":      {"s3cret"},
+		"password": {"correct horse"},
+	}
+
+	for range 3 {
+		report := schema.Validate(data)
+		must.True(t, report.Valid)
+	}
+	must.Zero(t, secrets)
+	must.Zero(t, denied)
+
+	report := schema.Validate(url.Values{"token": {"forged.token"}, "password": {"short"}})
+	must.False(t, report.Valid)
+	must.Eq(t, "invalid_token", report.Fields["token"].Code)
+	must.Eq(t, "no_value", report.Fields["key"].Code)
+	must.Eq(t, "password_policy", report.Fields["password"].Code)
+
+	// the token is still accepted by the real submission
+	err = ParseValues(data, schema)
+	must.NoError(t, err)
+	must.Eq(t, 1, secrets)
+	must.Eq(t, 1, denied)
+}
+
+func Test_Schema_Validate_Group_side_effects(t *testing.T) {
+	t.Parallel()
+
+	var password *conceal.Text
+	denied, secrets := 0, 0
+	schema := Schema{
+		"account": Group(Schema{
+			"key": SecretTo(countingSink{count: &secrets}),
+			"password": Password(&password, PasswordPolicy{
+				MinLength: 8,
+				Deny: func(string) error {
+					denied++
+					return nil
+				},
+			}),
+		}),
+	}
+	data := url.Values{
+		"account[key]":      {"s3cret"},
+		"account[password]": {"correct horse"},
+	}
+
+	report := schema.Validate(data)
+	must.True(t, report.Valid)
+	must.Zero(t, secrets)
+	must.Zero(t, denied)
+
+	err := ParseValues(data, schema)
+	must.NoError(t, err)
+	must.Eq(t, 1, secrets)
+	must.Eq(t, 1, denied)
+}
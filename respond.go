@@ -0,0 +1,104 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// A FieldMessage is the message of a single error, as given to the template of
+// a WriteOptions. The Field of an error not caused by any one field is empty.
+type FieldMessage struct {
+	Field   string
+	Label   string
+	Message string
+}
+
+// WriteOptions controls how WriteErrors responds to a request.
+type WriteOptions struct {
+	// Target is the CSS selector of the element to be replaced by the HTML
+	// fragment in response to an HTMX request, sent as HX-Retarget.
+	Target string
+
+	// Swap is the HTMX swap strategy, e.g. "outerHTML", sent as HX-Reswap.
+	Swap string
+
+	// Template renders the HTML fragment, given a []FieldMessage. If nil, the
+	// messages are rendered as a list.
+	Template *template.Template
+}
+
+var defaultErrorsTemplate = template.Must(template.New("errors").Parse(
+	`<ul class="form-errors">` +
+		`{{range .}}<li{{with .Field}} data-field="{{.}}"{{end}}>` +
+		`{{with .Label}}{{.}}: {{end}}{{.Message}}</li>{{end}}` +
+		`</ul>`,
+))
+
+// fieldMessages describes each error of err, or err as a whole if it did not
+// come from a field.
+func fieldMessages(err error) []FieldMessage {
+	errs := fieldErrors(err)
+	if len(errs) == 0 {
+		return []FieldMessage{{Message: err.Error()}}
+	}
+	fms := make([]FieldMessage, len(errs))
+	for i, fe := range errs {
+		fms[i] = FieldMessage{Field: fe.Field, Label: fe.Label, Message: fe.Err.Error()}
+	}
+	return fms
+}
+
+// wantsJSON reports whether r prefers a JSON response, as an API client would.
+func wantsJSON(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	return strings.Contains(accept, "application/json") || strings.Contains(accept, "application/problem+json")
+}
+
+// WriteErrors responds to r with a description of err, as returned by parsing
+// the form of r. An HTMX request (with the HX-Request header) is given an HTML
+// fragment of the errors, with the HX-Retarget and HX-Reswap headers set from
+// opts so the fragment can be placed next to the form. Note that HTMX must be
+// configured to swap the content of 422 responses. Otherwise, a request which
+// accepts JSON is given the ProblemDetails of err, and any other request the
+// HTML fragment alone. The status is that of ProblemDetails.
+func WriteErrors(w http.ResponseWriter, r *http.Request, err error, opts WriteOptions) error {
+	problem := ProblemDetails(err)
+	htmx := r.Header.Get("HX-Request") == "true"
+
+	if !htmx && wantsJSON(r) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(problem.Status)
+		return json.NewEncoder(w).Encode(problem)
+	}
+
+	tmpl := opts.Template
+	if tmpl == nil {
+		tmpl = defaultErrorsTemplate
+	}
+
+	// render first, so that a failing template does not leave a partial
+	// response behind
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, fieldMessages(err)); err != nil {
+		return err
+	}
+
+	if htmx {
+		if opts.Target != "" {
+			w.Header().Set("HX-Retarget", opts.Target)
+		}
+		if opts.Swap != "" {
+			w.Header().Set("HX-Reswap", opts.Swap)
+		}
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(problem.Status)
+	_, err = buf.WriteTo(w)
+	return err
+}
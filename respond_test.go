@@ -0,0 +1,81 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func signupErrors(t *testing.T) (*http.Request, error) {
+	r := postForm(t, url.Values{"age": {"old"}})
+	var s signup
+	err := Parse(r, Schema{
+		"name": Annotate(String(&s.Name), Label("Name")),
+		"age":  Int(&s.Age),
+	}, CollectAllErrors())
+	must.Error(t, err)
+	return r, err
+}
+
+func Test_WriteErrors_htmx(t *testing.T) {
+	t.Parallel()
+
+	r, err := signupErrors(t)
+	r.Header.Set("HX-Request", "true")
+	r.Header.Set("Accept", "application/json")
+
+	w := httptest.NewRecorder()
+	must.NoError(t, WriteErrors(w, r, err, WriteOptions{Target: "#signup-errors", Swap: "innerHTML"}))
+	must.Eq(t, http.StatusUnprocessableEntity, w.Code)
+	must.Eq(t, "#signup-errors", w.Header().Get("HX-Retarget"))
+	must.Eq(t, "innerHTML", w.Header().Get("HX-Reswap"))
+	must.Eq(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	must.Eq(t, `<ul class="form-errors">`+
+		`<li data-field="age">strconv.Atoi: parsing &#34;old&#34;: invalid syntax</li>`+
+		`<li data-field="name">Name: expected value to exist</li>`+
+		`</ul>`, w.Body.String())
+}
+
+func Test_WriteErrors_json(t *testing.T) {
+	t.Parallel()
+
+	r, err := signupErrors(t)
+	r.Header.Set("Accept", "application/json")
+
+	w := httptest.NewRecorder()
+	must.NoError(t, WriteErrors(w, r, err, WriteOptions{Target: "#signup-errors"}))
+	must.Eq(t, http.StatusUnprocessableEntity, w.Code)
+	must.Eq(t, "application/problem+json", w.Header().Get("Content-Type"))
+	must.Eq(t, "", w.Header().Get("HX-Retarget"))
+	must.StrContains(t, w.Body.String(), `"errors":{"age":`)
+}
+
+func Test_WriteErrors_template(t *testing.T) {
+	t.Parallel()
+
+	r, err := signupErrors(t)
+
+	tmpl := template.Must(template.New("").Parse(`{{range .}}[{{.Field}}]{{end}}`))
+	w := httptest.NewRecorder()
+	must.NoError(t, WriteErrors(w, r, err, WriteOptions{Template: tmpl}))
+	must.Eq(t, http.StatusUnprocessableEntity, w.Code)
+	must.Eq(t, "[age][name]", w.Body.String())
+	must.Eq(t, "", w.Header().Get("HX-Retarget"))
+}
+
+func Test_WriteErrors_not_field(t *testing.T) {
+	t.Parallel()
+
+	r := postForm(t, url.Values{})
+	w := httptest.NewRecorder()
+	must.NoError(t, WriteErrors(w, r, ErrBodyTooLarge, WriteOptions{}))
+	must.Eq(t, http.StatusRequestEntityTooLarge, w.Code)
+	must.Eq(t, `<ul class="form-errors"><li>request body too large</li></ul>`, w.Body.String())
+}
@@ -0,0 +1,129 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// A Result provides typed access to the values of a form without declaring a
+// Schema and destination variables up front. Each getter parses one field; the
+// first field which fails to parse is recorded and reported by Err, after
+// which getters continue to return zero values for failing fields.
+//
+//	res := forms.CollectValues(r.Form)
+//	name := res.String("name")
+//	age := res.IntOr("age", 18)
+//	if err := res.Err(); err != nil {
+//		// handle error
+//	}
+type Result struct {
+	data url.Values
+	err  error
+}
+
+// Collect parses the HTTP form values of r into a Result.
+func Collect(r *http.Request) (*Result, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	return CollectValues(r.Form), nil
+}
+
+// CollectValues creates a Result of data.
+func CollectValues(data url.Values) *Result {
+	return &Result{data: data}
+}
+
+// Err returns the error of the first field which failed to parse, if any.
+func (r *Result) Err() error {
+	return r.err
+}
+
+// Provided reports whether at least one value was submitted for name.
+func (r *Result) Provided(name string) bool {
+	return len(r.data[name]) > 0
+}
+
+func (r *Result) parse(name string, p Parser) {
+	o := new(options)
+	err := parseField(context.Background(), name, p, r.data, o)
+	for _, d := range o.deferred {
+		if err != nil {
+			break
+		}
+		err = d.apply(context.Background())
+	}
+	if _, ok := asWarning(err); ok {
+		return
+	}
+	if err != nil && r.err == nil {
+		r.err = Errors{{Field: name, Err: err}}
+	}
+}
+
+// String returns the value of name as a string, failing if it is missing.
+func (r *Result) String(name string) string {
+	var s string
+	r.parse(name, String(&s))
+	return s
+}
+
+// StringOr returns the value of name as a string, or alt if it is missing.
+func (r *Result) StringOr(name, alt string) string {
+	var s string
+	r.parse(name, StringOr(&s, alt))
+	return s
+}
+
+// Strings returns every value of name, failing if there are none.
+func (r *Result) Strings(name string) []string {
+	var s []string
+	r.parse(name, Strings(&s))
+	return s
+}
+
+// Int returns the value of name as an int, failing if it is missing.
+func (r *Result) Int(name string) int {
+	var i int
+	r.parse(name, Int(&i))
+	return i
+}
+
+// IntOr returns the value of name as an int, or alt if it is missing.
+func (r *Result) IntOr(name string, alt int) int {
+	var i int
+	r.parse(name, IntOr(&i, alt))
+	return i
+}
+
+// Float returns the value of name as a float64, failing if it is missing.
+func (r *Result) Float(name string) float64 {
+	var f float64
+	r.parse(name, Float(&f))
+	return f
+}
+
+// FloatOr returns the value of name as a float64, or alt if it is missing.
+func (r *Result) FloatOr(name string, alt float64) float64 {
+	var f float64
+	r.parse(name, FloatOr(&f, alt))
+	return f
+}
+
+// Bool returns the value of name as a bool, failing if it is missing.
+func (r *Result) Bool(name string) bool {
+	var b bool
+	r.parse(name, Bool(&b))
+	return b
+}
+
+// BoolOr returns the value of name as a bool, or alt if it is missing.
+func (r *Result) BoolOr(name string, alt bool) bool {
+	var b bool
+	r.parse(name, BoolOr(&b, alt))
+	return b
+}
@@ -0,0 +1,61 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Result(t *testing.T) {
+	t.Parallel()
+
+	res := CollectValues(url.Values{
+		"name":    {"bob"},
+		"age":     {"42"},
+		"worth":   {"1.5"},
+		"admin":   {"on"},
+		"aliases": {"b", "bobby"},
+	})
+
+	must.Eq(t, "bob", res.String("name"))
+	must.Eq(t, "x", res.StringOr("nick", "x"))
+	must.Eq(t, []string{"b", "bobby"}, res.Strings("aliases"))
+	must.Eq(t, 42, res.Int("age"))
+	must.Eq(t, 7, res.IntOr("rank", 7))
+	must.Eq(t, 1.5, res.Float("worth"))
+	must.Eq(t, 2.5, res.FloatOr("debt", 2.5))
+	must.True(t, res.Bool("admin"))
+	must.True(t, res.BoolOr("active", true))
+	must.True(t, res.Provided("name"))
+	must.False(t, res.Provided("nick"))
+	must.NoError(t, res.Err())
+}
+
+func Test_Result_Err(t *testing.T) {
+	t.Parallel()
+
+	res := CollectValues(url.Values{
+		"age": {"old"},
+	})
+
+	must.Eq(t, 0, res.Int("age"))
+	must.Eq(t, "", res.String("name"))
+	must.Error(t, res.Err())
+
+	var fe *FieldError
+	must.True(t, errors.As(res.Err(), &fe))
+	must.Eq(t, "age", fe.Field)
+}
+
+func Test_Collect(t *testing.T) {
+	t.Parallel()
+
+	res, err := Collect(postForm(t, url.Values{"name": {"alice"}}))
+	must.NoError(t, err)
+	must.Eq(t, "alice", res.String("name"))
+}
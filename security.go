@@ -0,0 +1,66 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"fmt"
+)
+
+type honeypotParser struct{}
+
+// describe reports the honeypot as multiple so that no multi-value policy can
+// hide a filled-in copy of the field.
+func (p *honeypotParser) describe() field {
+	return field{kind: "honeypot", required: true, multiple: true, constraints: []string{"must be empty"}}
+}
+
+func (p *honeypotParser) Parse(values []string) error {
+	switch {
+	case len(values) == 0:
+		return ErrNoValue
+	case len(values) > 1, values[0] != "":
+		return ErrSpamSuspected
+	}
+	return nil
+}
+
+// Honeypot is used to require a form field which is hidden from humans, and
+// so is always submitted empty by a browser but is often filled in by bots.
+// If the field contains any value then ErrSpamSuspected is returned during
+// parsing, and if the field is missing then ErrNoValue is returned.
+func Honeypot() Parser {
+	return new(honeypotParser)
+}
+
+type csrfParser struct {
+	verify func(token string) error
+}
+
+func (p *csrfParser) describe() field {
+	return field{kind: "csrf token", required: true, sensitive: true}
+}
+
+func (p *csrfParser) Parse(values []string) error {
+	switch {
+	case len(values) == 0:
+		return ErrNoValue
+	case len(values) > 1:
+		return ErrMulitpleValues
+	}
+
+	if err := p.verify(values[0]); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	return nil
+}
+
+// CSRFToken is used to extract the hidden CSRF token field of a form and check
+// it using verify, so that CSRF protection is declared alongside the rest of
+// the form. If verify returns an error, or the token is missing, then an error
+// wrapping ErrInvalidToken or ErrNoValue is returned during parsing.
+func CSRFToken(verify func(token string) error) Parser {
+	return &csrfParser{
+		verify: verify,
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_Honeypot(t *testing.T) {
+	t.Parallel()
+
+	err := ParseValues(url.Values{"website": {""}}, Schema{
+		"website": Honeypot(),
+	})
+	must.NoError(t, err)
+
+	err = ParseValues(url.Values{"website": {"http://spam.example"}}, Schema{
+		"website": Honeypot(),
+	})
+	must.ErrorIs(t, err, ErrSpamSuspected)
+
+	err = ParseValues(url.Values{"website": {"", "x"}}, Schema{
+		"website": Honeypot(),
+	}, MultipleValues(TakeFirst))
+	must.ErrorIs(t, err, ErrSpamSuspected)
+
+	err = ParseValues(url.Values{}, Schema{
+		"website": Honeypot(),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
+
+func Test_Parse_CSRFToken(t *testing.T) {
+	t.Parallel()
+
+	mismatch := errors.New("token mismatch")
+	verify := func(token string) error {
+		if token != "s3cret" {
+			return mismatch
+		}
+		return nil
+	}
+
+	var name string
+
+	err := ParseValues(url.Values{"csrf": {"s3cret"}, "name": {"bob"}}, Schema{
+		"csrf": CSRFToken(verify),
+		"name": String(&name),
+	})
+	must.NoError(t, err)
+
+	err = ParseValues(url.Values{"csrf": {"forged"}, "name": {"bob"}}, Schema{
+		"csrf": CSRFToken(verify),
+		"name": String(&name),
+	})
+	must.ErrorIs(t, err, ErrInvalidToken)
+	must.ErrorIs(t, err, mismatch)
+
+	err = ParseValues(url.Values{"name": {"bob"}}, Schema{
+		"csrf": CSRFToken(verify),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
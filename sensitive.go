@@ -0,0 +1,26 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+type sensitiveParser struct {
+	Parser
+}
+
+func (p *sensitiveParser) describe() field {
+	f := describe(p.Parser)
+	f.sensitive = true
+	return f
+}
+
+func (p *sensitiveParser) destinations() []any {
+	return destinations(p.Parser)
+}
+
+// Sensitive wraps p to mark the value it parses as sensitive, so that the value
+// is never included in logs or other records produced by this package.
+func Sensitive(p Parser) Parser {
+	return &sensitiveParser{
+		Parser: p,
+	}
+}
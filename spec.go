@@ -0,0 +1,330 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// A Spec is a declarative definition of a form, which may be loaded at run
+// time (e.g. from a JSON document configured by an administrator) and bound to
+// a map instead of Go variables, so the form can be validated without being
+// compiled into the program.
+//
+//	{
+//	  "fields": [
+//	    {"name": "email", "type": "string", "required": true, "pattern": "@"},
+//	    {"name": "age", "type": "int", "min": 18, "default": 21},
+//	    {"name": "plan", "type": "choice", "choices": ["free", "pro"]}
+//	  ]
+//	}
+type Spec struct {
+	Fields []FieldSpec `json:"fields"`
+
+	once     sync.Once
+	err      error
+	patterns map[string]*regexp.Regexp
+}
+
+// A FieldSpec describes one field of a Spec.
+type FieldSpec struct {
+	// Name is the name of the form field, and the key of its value in the
+	// map bound to the Spec.
+	Name string `json:"name"`
+
+	// Type is one of "string", "int", "float", "bool", "strings", or
+	// "choice", which determines the type of the value stored in the map:
+	// string, int, float64, bool, []string, and string respectively.
+	Type string `json:"type"`
+
+	// Required causes parsing to fail if the field is missing.
+	Required bool `json:"required,omitempty"`
+
+	// Default is stored in the map if an optional field is missing. If there
+	// is no default, nothing is stored for the missing field.
+	Default any `json:"default,omitempty"`
+
+	// Min and Max bound the value of an "int" or "float" field.
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+
+	// MinLength, MaxLength, and Pattern constrain each value of a "string"
+	// or "strings" field, with lengths counted in characters.
+	MinLength int    `json:"min_length,omitempty"`
+	MaxLength int    `json:"max_length,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+
+	// Choices are the values accepted by a "choice" field.
+	Choices []string `json:"choices,omitempty"`
+
+	// Label and Help are the Metadata of the field.
+	Label string `json:"label,omitempty"`
+	Help  string `json:"help,omitempty"`
+}
+
+// DecodeSpec reads a Spec encoded as JSON from r, and checks it is valid.
+// Unknown members of the document are rejected, so that misspelled constraints
+// are not silently ignored.
+func DecodeSpec(r io.Reader) (*Spec, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	s := new(Spec)
+	if err := decoder.Decode(s); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+	if err := s.Check(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Check reports mistakes in s, such as duplicate field names, unknown types,
+// constraints which do not apply to the type of their field, and defaults
+// which are not valid values of their field. The returned error wraps
+// ErrInvalidSchema. A Spec must not be modified after it is first checked.
+func (s *Spec) Check() error {
+	s.once.Do(func() {
+		s.patterns = make(map[string]*regexp.Regexp)
+		s.err = s.check()
+	})
+	return s.err
+}
+
+func (s *Spec) check() error {
+	seen := make(map[string]bool)
+	for _, fs := range s.Fields {
+		switch {
+		case fs.Name == "":
+			return fmt.Errorf("%w: empty field name", ErrInvalidSchema)
+		case seen[fs.Name]:
+			return fmt.Errorf("%w: field %q is defined more than once", ErrInvalidSchema, fs.Name)
+		}
+		seen[fs.Name] = true
+
+		if err := s.checkField(fs); err != nil {
+			return fmt.Errorf("%w: field %q %w", ErrInvalidSchema, fs.Name, err)
+		}
+	}
+	return nil
+}
+
+func (s *Spec) checkField(fs FieldSpec) error {
+	text := fs.Type == "string" || fs.Type == "strings"
+	number := fs.Type == "int" || fs.Type == "float"
+	switch {
+	case !text && !number && fs.Type != "bool" && fs.Type != "choice":
+		return fmt.Errorf("has unknown type %q", fs.Type)
+	case !number && (fs.Min != nil || fs.Max != nil):
+		return fmt.Errorf("of type %q cannot have min or max", fs.Type)
+	case fs.Min != nil && fs.Max != nil && *fs.Min > *fs.Max:
+		return errors.New("has min greater than max")
+	case !text && (fs.MinLength != 0 || fs.MaxLength != 0 || fs.Pattern != ""):
+		return fmt.Errorf("of type %q cannot have a length or pattern", fs.Type)
+	case fs.MaxLength != 0 && fs.MinLength > fs.MaxLength:
+		return errors.New("has min_length greater than max_length")
+	case fs.Type == "choice" && len(fs.Choices) == 0:
+		return errors.New("has no choices")
+	case fs.Type != "choice" && len(fs.Choices) > 0:
+		return fmt.Errorf("of type %q cannot have choices", fs.Type)
+	case fs.Required && fs.Default != nil:
+		return errors.New("is required and cannot have a default")
+	}
+
+	if fs.Pattern != "" {
+		re, err := regexp.Compile(fs.Pattern)
+		if err != nil {
+			return fmt.Errorf("has invalid pattern: %w", err)
+		}
+		s.patterns[fs.Name] = re
+	}
+
+	if fs.Default != nil {
+		if _, err := s.parser(fs, nil).value(defaultValues(fs.Default)); err != nil {
+			return fmt.Errorf("has invalid default: %w", err)
+		}
+	}
+	return nil
+}
+
+// defaultValues renders the JSON value v as form values.
+func defaultValues(v any) []string {
+	list, ok := v.([]any)
+	if !ok {
+		list = []any{v}
+	}
+	values := make([]string, len(list))
+	for i, item := range list {
+		if f, ok := item.(float64); ok {
+			// a JSON number is decoded as a float64, which fmt.Sprint may
+			// write in exponent form
+			values[i] = strconv.FormatFloat(f, 'f', -1, 64)
+			continue
+		}
+		values[i] = fmt.Sprint(item)
+	}
+	return values
+}
+
+// Bind returns a Schema which parses a form described by s, storing the value
+// of each field in dst under its name. Bind is called for every parse, with a
+// new map, so that the Spec may be shared by concurrent requests.
+func (s *Spec) Bind(dst map[string]any) (Schema, error) {
+	if err := s.Check(); err != nil {
+		return nil, err
+	}
+
+	schema := make(Schema, len(s.Fields))
+	for _, fs := range s.Fields {
+		var p Parser = s.parser(fs, dst)
+		if fs.Label != "" || fs.Help != "" {
+			p = Annotate(p, Label(fs.Label), Help(fs.Help))
+		}
+		schema[fs.Name] = p
+	}
+	return schema, nil
+}
+
+func (s *Spec) parser(fs FieldSpec, dst map[string]any) *specParser {
+	p := &specParser{spec: fs, store: dst}
+	if fs.MinLength > 0 {
+		p.steps = append(p.steps, MinLen(fs.MinLength))
+	}
+	if fs.MaxLength > 0 {
+		p.steps = append(p.steps, MaxLen(fs.MaxLength))
+	}
+	if re := s.patterns[fs.Name]; re != nil {
+		p.steps = append(p.steps, Match(re))
+	}
+	return p
+}
+
+type specParser struct {
+	spec  FieldSpec
+	steps []Step
+	store map[string]any
+}
+
+func (p *specParser) describe() field {
+	f := field{
+		kind:     p.spec.Type,
+		required: p.spec.Required,
+		multiple: p.spec.Type == "strings",
+		alt:      p.spec.Default,
+	}
+	if p.spec.Type == "choice" {
+		f.constraints = append(f.constraints, "one of "+quoted(p.spec.Choices))
+	}
+	if p.spec.Min != nil {
+		f.constraints = append(f.constraints, "at least "+strconv.FormatFloat(*p.spec.Min, 'g', -1, 64))
+	}
+	if p.spec.Max != nil {
+		f.constraints = append(f.constraints, "at most "+strconv.FormatFloat(*p.spec.Max, 'g', -1, 64))
+	}
+	for _, step := range p.steps {
+		f.constraints = append(f.constraints, step.description)
+	}
+	return f
+}
+
+func (p *specParser) Parse(values []string) error {
+	v, found, err := p.parse(values)
+	if err != nil || !found {
+		return err
+	}
+	p.store[p.spec.Name] = v
+	return nil
+}
+
+// dryRun checks values without storing them, as the map given to Bind is not
+// restored by Schema.Validate.
+func (p *specParser) dryRun(values []string) error {
+	_, _, err := p.parse(values)
+	return err
+}
+
+// parse returns the value of the field, reporting false if an optional field
+// without a default is missing.
+func (p *specParser) parse(values []string) (any, bool, error) {
+	if len(values) == 0 && !p.spec.Required {
+		if p.spec.Default == nil {
+			return nil, false, nil
+		}
+		values = defaultValues(p.spec.Default)
+	}
+
+	v, err := p.value(values)
+	return v, err == nil, err
+}
+
+// value parses values into the Go value of the field.
+func (p *specParser) value(values []string) (any, error) {
+	cleaned := make([]string, len(values))
+	for i, value := range values {
+		for _, step := range p.steps {
+			var err error
+			if value, err = step.apply(value); err != nil {
+				return nil, err
+			}
+		}
+		cleaned[i] = value
+	}
+
+	var v any
+	var err error
+	switch p.spec.Type {
+	case "string":
+		var s string
+		err = String(&s).Parse(cleaned)
+		v = s
+	case "strings":
+		var s []string
+		err = Strings(&s).Parse(cleaned)
+		v = s
+	case "bool":
+		var b bool
+		err = Bool(&b).Parse(cleaned)
+		v = b
+	case "choice":
+		choices := make(map[string]string, len(p.spec.Choices))
+		for _, choice := range p.spec.Choices {
+			choices[choice] = choice
+		}
+		var s string
+		err = Radio(&s, choices).Parse(cleaned)
+		v = s
+	case "int":
+		var i int
+		if err = Int(&i).Parse(cleaned); err == nil {
+			err = p.bounds(float64(i))
+		}
+		v = i
+	default:
+		var f float64
+		if err = Float(&f).Parse(cleaned); err == nil {
+			err = p.bounds(f)
+		}
+		v = f
+	}
+	return v, err
+}
+
+func (p *specParser) bounds(n float64) error {
+	lo, hi := p.spec.Min, p.spec.Max
+	switch {
+	case lo != nil && hi != nil && (n < *lo || n > *hi):
+		return fmt.Errorf("%w: must be between %g and %g", ErrOutOfRange, *lo, *hi)
+	case lo != nil && n < *lo:
+		return fmt.Errorf("%w: must be at least %g", ErrOutOfRange, *lo)
+	case hi != nil && n > *hi:
+		return fmt.Errorf("%w: must be at most %g", ErrOutOfRange, *hi)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+const planSpec = `{
+  "fields": [
+    {"name": "email", "type": "string", "required": true, "pattern": "@", "max_length": 20, "label": "Email address"},
+    {"name": "age", "type": "int", "min": 18, "max": 120, "default": 21},
+    {"name": "ratio", "type": "float", "max": 1},
+    {"name": "plan", "type": "choice", "choices": ["free", "pro"], "default": "free"},
+    {"name": "tags", "type": "strings", "default": ["new"]},
+    {"name": "terms", "type": "bool", "required": true}
+  ]
+}`
+
+func Test_Spec_Bind(t *testing.T) {
+	t.Parallel()
+
+	spec, err := DecodeSpec(strings.NewReader(planSpec))
+	must.NoError(t, err)
+
+	values := make(map[string]any)
+	schema, err := spec.Bind(values)
+	must.NoError(t, err)
+
+	err = ParseValues(url.Values{
+		"email": {"bob@example.com"},
+		"age":   {"30"},
+		"tags":  {"a", "b"},
+		"terms": {"true"},
+	}, schema, Strict())
+	must.NoError(t, err)
+	must.Eq(t, map[string]any{
+		"email": "bob@example.com",
+		"age":   30,
+		"plan":  "free",
+		"tags":  []string{"a", "b"},
+		"terms": true,
+	}, values)
+
+	values = make(map[string]any)
+	schema, err = spec.Bind(values)
+	must.NoError(t, err)
+	err = ParseValues(url.Values{
+		"email": {"alice@example.com"},
+		"ratio": {"0.5"},
+		"plan":  {"pro"},
+		"terms": {"false"},
+	}, schema)
+	must.NoError(t, err)
+	must.Eq[any](t, 21, values["age"])
+	must.Eq[any](t, 0.5, values["ratio"])
+	must.Eq[any](t, []string{"new"}, values["tags"])
+
+	fields := schema.Fields()
+	must.Eq(t, "age", fields[0].Name)
+	must.Eq(t, "int", fields[0].Kind)
+	must.Eq(t, "Email address", fields[1].Metadata.Label)
+}
+
+func Test_DecodeSpec_large_default(t *testing.T) {
+	t.Parallel()
+
+	spec, err := DecodeSpec(strings.NewReader(`{"fields": [{"name": "quota", "type": "int", "default": 1000000}]}`))
+	must.NoError(t, err)
+
+	values := make(map[string]any)
+	schema, err := spec.Bind(values)
+	must.NoError(t, err)
+	err = ParseValues(url.Values{}, schema)
+	must.NoError(t, err)
+	must.Eq[any](t, 1000000, values["quota"])
+}
+
+func Test_Spec_Validate(t *testing.T) {
+	t.Parallel()
+
+	spec, err := DecodeSpec(strings.NewReader(planSpec))
+	must.NoError(t, err)
+
+	values := map[string]any{"plan": "pro"}
+	schema, err := spec.Bind(values)
+	must.NoError(t, err)
+
+	report := schema.Validate(url.Values{
+		"email": {"bob@example.com"},
+		"terms": {"true"},
+	})
+	must.True(t, report.Valid)
+	must.Eq(t, map[string]any{"plan": "pro"}, values)
+
+	report = schema.Validate(url.Values{"age": {"12"}})
+	must.False(t, report.Valid)
+	must.Eq(t, "out_of_range", report.Fields["age"].Code)
+	must.Eq(t, map[string]any{"plan": "pro"}, values)
+}
+
+func Test_Spec_Bind_invalid(t *testing.T) {
+	t.Parallel()
+
+	spec, err := DecodeSpec(strings.NewReader(planSpec))
+	must.NoError(t, err)
+
+	cases := []struct {
+		name string
+		data url.Values
+		exp  error
+	}{
+		{name: "missing", data: url.Values{"terms": {"true"}}, exp: ErrNoValue},
+		{name: "pattern", data: url.Values{"email": {"bob"}, "terms": {"true"}}, exp: ErrPattern},
+		{name: "length", data: url.Values{"email": {"bob@example.com.invalid"}, "terms": {"true"}}, exp: ErrLength},
+		{name: "min", data: url.Values{"email": {"a@b"}, "age": {"12"}, "terms": {"true"}}, exp: ErrOutOfRange},
+		{name: "max", data: url.Values{"email": {"a@b"}, "ratio": {"1.5"}, "terms": {"true"}}, exp: ErrOutOfRange},
+		{name: "choice", data: url.Values{"email": {"a@b"}, "plan": {"gold"}, "terms": {"true"}}, exp: ErrInvalidChoice},
+	}
+	for _, tc := range cases {
+		values := make(map[string]any)
+		schema, err := spec.Bind(values)
+		must.NoError(t, err)
+		err = ParseValues(tc.data, schema)
+		must.ErrorIs(t, err, tc.exp, must.Sprint(tc.name))
+	}
+
+	values := make(map[string]any)
+	schema, err := spec.Bind(values)
+	must.NoError(t, err)
+	err = ParseValues(url.Values{"terms": {"true"}}, schema)
+	var fe *FieldError
+	must.True(t, errors.As(err, &fe))
+	must.Eq(t, "Email address", fe.Label)
+}
+
+func Test_DecodeSpec_invalid(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"unknown member":   `{"fields": [{"name": "a", "type": "string", "maximum": 3}]}`,
+		"empty name":       `{"fields": [{"type": "string"}]}`,
+		"duplicate":        `{"fields": [{"name": "a", "type": "string"}, {"name": "a", "type": "int"}]}`,
+		"unknown type":     `{"fields": [{"name": "a", "type": "date"}]}`,
+		"min on string":    `{"fields": [{"name": "a", "type": "string", "min": 1}]}`,
+		"min above max":    `{"fields": [{"name": "a", "type": "int", "min": 5, "max": 1}]}`,
+		"pattern on int":   `{"fields": [{"name": "a", "type": "int", "pattern": "x"}]}`,
+		"bad pattern":      `{"fields": [{"name": "a", "type": "string", "pattern": "("}]}`,
+		"no choices":       `{"fields": [{"name": "a", "type": "choice"}]}`,
+		"bad default":      `{"fields": [{"name": "a", "type": "int", "default": "ten"}]}`,
+		"default range":    `{"fields": [{"name": "a", "type": "int", "max": 5, "default": 10}]}`,
+		"required default": `{"fields": [{"name": "a", "type": "int", "required": true, "default": 1}]}`,
+	}
+	for name, document := range cases {
+		_, err := DecodeSpec(strings.NewReader(document))
+		must.ErrorIs(t, err, ErrInvalidSchema, must.Sprint(name))
+	}
+
+	spec := &Spec{Fields: []FieldSpec{{Name: "a", Type: "uuid"}}}
+	_, err := spec.Bind(make(map[string]any))
+	must.ErrorIs(t, err, ErrInvalidSchema)
+}
@@ -0,0 +1,173 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"database/sql"
+	"time"
+)
+
+type nullParser[T any] struct {
+	kind   string
+	parser func(*T) Parser
+	// blank indicates whether an empty value is treated as missing
+	blank       bool
+	set         func(v T, valid bool)
+	destination any
+}
+
+func (p *nullParser[T]) describe() field {
+	f := field{kind: p.kind, required: false}
+	if p.blank {
+		f.constraints = []string{"missing or empty is null"}
+	} else {
+		f.constraints = []string{"missing is null"}
+	}
+	return f
+}
+
+func (p *nullParser[T]) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *nullParser[T]) Parse(values []string) error {
+	var v T
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0, p.blank && values[0] == "":
+		p.set(v, false)
+		return nil
+	}
+
+	if err := p.parser(&v).Parse(values); err != nil {
+		return err
+	}
+
+	p.set(v, true)
+	return nil
+}
+
+// NullString is used to extract a form data value into a Go sql.NullString. If
+// the value is missing then Valid is set to false. An empty value is a valid
+// empty string.
+func NullString(s *sql.NullString) Parser {
+	return &nullParser[string]{
+		kind:   "string",
+		parser: String[string],
+		set: func(v string, valid bool) {
+			*s = sql.NullString{String: v, Valid: valid}
+		},
+		destination: s,
+	}
+}
+
+// NullInt64 is used to extract a form data value into a Go sql.NullInt64. If
+// the value is missing or empty then Valid is set to false. If the value is
+// not an int then an error is returned during parsing.
+func NullInt64(i *sql.NullInt64) Parser {
+	return &nullParser[int64]{
+		kind:   "int",
+		parser: Int[int64],
+		blank:  true,
+		set: func(v int64, valid bool) {
+			*i = sql.NullInt64{Int64: v, Valid: valid}
+		},
+		destination: i,
+	}
+}
+
+// NullFloat64 is used to extract a form data value into a Go sql.NullFloat64.
+// If the value is missing or empty then Valid is set to false. If the value is
+// not a float then an error is returned during parsing.
+func NullFloat64(f *sql.NullFloat64) Parser {
+	return &nullParser[float64]{
+		kind:   "float",
+		parser: Float,
+		blank:  true,
+		set: func(v float64, valid bool) {
+			*f = sql.NullFloat64{Float64: v, Valid: valid}
+		},
+		destination: f,
+	}
+}
+
+// NullBool is used to extract a form data value into a Go sql.NullBool. If the
+// value is missing or empty then Valid is set to false. If the value is not a
+// bool then an error is returned during parsing.
+func NullBool(b *sql.NullBool) Parser {
+	return &nullParser[bool]{
+		kind:   "bool",
+		parser: Bool,
+		blank:  true,
+		set: func(v bool, valid bool) {
+			*b = sql.NullBool{Bool: v, Valid: valid}
+		},
+		destination: b,
+	}
+}
+
+type timeParser struct {
+	layout      string
+	destination *time.Time
+}
+
+func (p *timeParser) Parse(values []string) error {
+	t, err := time.Parse(p.layout, values[0])
+	if err != nil {
+		return err
+	}
+	*p.destination = t
+	return nil
+}
+
+// NullTime is used to extract a form data value into a Go sql.NullTime, using
+// layout as understood by time.Parse. If the value is missing or empty then
+// Valid is set to false. If the value does not match layout then an error is
+// returned during parsing.
+func NullTime(t *sql.NullTime, layout string) Parser {
+	return &nullParser[time.Time]{
+		kind: "time",
+		parser: func(dst *time.Time) Parser {
+			return &timeParser{layout: layout, destination: dst}
+		},
+		blank: true,
+		set: func(v time.Time, valid bool) {
+			*t = sql.NullTime{Time: v, Valid: valid}
+		},
+		destination: t,
+	}
+}
+
+type scanParser struct {
+	destination sql.Scanner
+}
+
+func (p *scanParser) describe() field {
+	return field{kind: "scanner", required: true}
+}
+
+func (p *scanParser) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *scanParser) Parse(values []string) error {
+	switch {
+	case len(values) > 1:
+		return ErrMulitpleValues
+	case len(values) == 0:
+		return ErrNoValue
+	}
+	return p.destination.Scan(values[0])
+}
+
+// Scan is used to extract a form data value into any Go type implementing
+// sql.Scanner, by passing the value to its Scan method as a string. If the
+// value is missing, or is rejected by Scan, then an error is returned during
+// parsing.
+func Scan(s sql.Scanner) Parser {
+	return &scanParser{
+		destination: s,
+	}
+}
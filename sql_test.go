@@ -0,0 +1,143 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"database/sql"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_Null_present(t *testing.T) {
+	t.Parallel()
+
+	data := url.Values{
+		"name":   {"bob"},
+		"age":    {"42"},
+		"height": {"1.8"},
+		"member": {"on"},
+		"born":   {"1982-03-04"},
+	}
+
+	var (
+		name   sql.NullString
+		age    sql.NullInt64
+		height sql.NullFloat64
+		member sql.NullBool
+		born   sql.NullTime
+	)
+
+	err := ParseValues(data, Schema{
+		"name":   NullString(&name),
+		"age":    NullInt64(&age),
+		"height": NullFloat64(&height),
+		"member": NullBool(&member),
+		"born":   NullTime(&born, time.DateOnly),
+	})
+	must.NoError(t, err)
+	must.Eq(t, sql.NullString{String: "bob", Valid: true}, name)
+	must.Eq(t, sql.NullInt64{Int64: 42, Valid: true}, age)
+	must.Eq(t, sql.NullFloat64{Float64: 1.8, Valid: true}, height)
+	must.Eq(t, sql.NullBool{Bool: true, Valid: true}, member)
+	must.Eq(t, sql.NullTime{Time: time.Date(1982, 3, 4, 0, 0, 0, 0, time.UTC), Valid: true}, born)
+}
+
+func Test_Parse_Null_missing(t *testing.T) {
+	t.Parallel()
+
+	name := sql.NullString{String: "stale", Valid: true}
+	age := sql.NullInt64{Int64: 1, Valid: true}
+	var (
+		blank  sql.NullString
+		height sql.NullFloat64
+		member sql.NullBool
+		born   sql.NullTime
+	)
+
+	err := ParseValues(url.Values{"blank": {""}, "age": {""}, "born": {""}}, Schema{
+		"name":   NullString(&name),
+		"blank":  NullString(&blank),
+		"age":    NullInt64(&age),
+		"height": NullFloat64(&height),
+		"member": NullBool(&member),
+		"born":   NullTime(&born, time.DateOnly),
+	})
+	must.NoError(t, err)
+	must.Eq(t, sql.NullString{}, name)
+	must.Eq(t, sql.NullString{Valid: true}, blank)
+	must.Eq(t, sql.NullInt64{}, age)
+	must.Eq(t, sql.NullFloat64{}, height)
+	must.Eq(t, sql.NullBool{}, member)
+	must.Eq(t, sql.NullTime{}, born)
+}
+
+func Test_Parse_Null_invalid(t *testing.T) {
+	t.Parallel()
+
+	var (
+		age  sql.NullInt64
+		born sql.NullTime
+	)
+
+	err := ParseValues(url.Values{"age": {"old"}}, Schema{
+		"age": NullInt64(&age),
+	})
+	must.Error(t, err)
+
+	err = ParseValues(url.Values{"born": {"yesterday"}}, Schema{
+		"born": NullTime(&born, time.DateOnly),
+	})
+	must.Error(t, err)
+
+	err = ParseValues(url.Values{"age": {"1", "2"}}, Schema{
+		"age": NullInt64(&age),
+	})
+	must.ErrorIs(t, err, ErrMulitpleValues)
+}
+
+type ticketID int
+
+func (id *ticketID) Scan(src any) error {
+	s, ok := src.(string)
+	if !ok || len(s) < 4 || s[:3] != "TK-" {
+		return errors.New("not a ticket id")
+	}
+	var n int
+	if err := Int(&n).Parse([]string{s[3:]}); err != nil {
+		return err
+	}
+	*id = ticketID(n)
+	return nil
+}
+
+func Test_Parse_Scan(t *testing.T) {
+	t.Parallel()
+
+	var (
+		id   ticketID
+		name sql.NullString
+	)
+
+	err := ParseValues(url.Values{"id": {"TK-1234"}, "name": {"bob"}}, Schema{
+		"id":   Scan(&id),
+		"name": Scan(&name),
+	})
+	must.NoError(t, err)
+	must.Eq(t, ticketID(1234), id)
+	must.Eq(t, sql.NullString{String: "bob", Valid: true}, name)
+
+	err = ParseValues(url.Values{"id": {"1234"}}, Schema{
+		"id": Scan(&id),
+	})
+	must.Error(t, err)
+
+	err = ParseValues(url.Values{}, Schema{
+		"id": Scan(&id),
+	})
+	must.ErrorIs(t, err, ErrNoValue)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// A StoredFile describes an uploaded file which has been written somewhere by
+// SaveTo or CopyTo.
+type StoredFile struct {
+	// Filename is the name of the file as given by the client. It must not
+	// be trusted as a path.
+	Filename string
+
+	// Path is the location the file was written to by SaveTo, and is
+	// empty for CopyTo.
+	Path string
+
+	// Size is the number of bytes written.
+	Size int64
+
+	// SHA256 is the hex encoded SHA-256 checksum of the content.
+	SHA256 string
+}
+
+// copyFile writes the content of fh to w, recording it in stored.
+func copyFile(w io.Writer, fh *multipart.FileHeader, stored *StoredFile) error {
+	f, err := fh.Open()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(w, h), f)
+	if err != nil {
+		return err
+	}
+
+	stored.Filename = fh.Filename
+	stored.Size = n
+	stored.SHA256 = hex.EncodeToString(h.Sum(nil))
+	return nil
+}
+
+// extension returns the extension of filename if it is safe to reuse in the
+// name of a stored file.
+func extension(filename string) string {
+	ext := filepath.Ext(filepath.Base(filename))
+	if len(ext) < 2 || len(ext) > 16 {
+		return ""
+	}
+	if strings.ContainsFunc(ext[1:], func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+	}) {
+		return ""
+	}
+	return ext
+}
+
+// saveFile writes the content of fh to a new file in dir, recording it in
+// stored. The name of the file is chosen randomly so that it never collides
+// with an existing file, keeping only the extension given by the client.
+func saveFile(dir string, fh *multipart.FileHeader, stored *StoredFile) error {
+	f, err := os.CreateTemp(dir, "upload-*"+extension(fh.Filename))
+	if err != nil {
+		return err
+	}
+
+	if err = copyFile(f, fh, stored); err == nil {
+		err = f.Close()
+	} else {
+		_ = f.Close()
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	stored.Path = f.Name()
+	return nil
+}
+
+// SaveTo is used to write an uploaded file of a multipart form into a new file
+// in the directory dir, describing it in stored. The file is given a unique
+// name, so existing files are never overwritten. If the file is missing, does
+// not satisfy the given constraints, or cannot be written, then an error is
+// returned during parsing.
+func SaveTo(dir string, stored *StoredFile, constraints ...FileConstraint) Parser {
+	return &fileParser{
+		rules: newFileRules(constraints),
+		set: func(fh *multipart.FileHeader) error {
+			return saveFile(dir, fh, stored)
+		},
+		destination: stored,
+	}
+}
+
+// CopyTo is used to write an uploaded file of a multipart form into w,
+// describing it in stored. If the file is missing, does not satisfy the given
+// constraints, or cannot be written, then an error is returned during parsing.
+func CopyTo(w io.Writer, stored *StoredFile, constraints ...FileConstraint) Parser {
+	return &fileParser{
+		rules: newFileRules(constraints),
+		set: func(fh *multipart.FileHeader) error {
+			return copyFile(w, fh, stored)
+		},
+		destination: stored,
+	}
+}
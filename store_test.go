@@ -0,0 +1,95 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func checksum(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func Test_Parse_SaveTo(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	var first, second StoredFile
+	for _, stored := range []*StoredFile{&first, &second} {
+		r := postMultipart(t, nil,
+			upload{field: "photo", filename: "cat.png", content: pngHeader},
+		)
+		err := Parse(r, Schema{
+			"photo": SaveTo(dir, stored, AllowTypes("image/png")),
+		})
+		must.NoError(t, err)
+	}
+
+	must.Eq(t, "cat.png", first.Filename)
+	must.Eq(t, dir, filepath.Dir(first.Path))
+	must.Eq(t, ".png", filepath.Ext(first.Path))
+	must.Eq(t, int64(len(pngHeader)), first.Size)
+	must.Eq(t, checksum(pngHeader), first.SHA256)
+	must.NotEq(t, first.Path, second.Path)
+
+	content, err := os.ReadFile(first.Path)
+	must.NoError(t, err)
+	must.Eq(t, pngHeader, content)
+}
+
+func Test_Parse_SaveTo_rejected(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	r := postMultipart(t, nil,
+		upload{field: "photo", filename: "cat.png", content: []byte("plain text")},
+	)
+
+	var stored StoredFile
+	err := Parse(r, Schema{
+		"photo": SaveTo(dir, &stored, AllowTypes("image/png")),
+	})
+	must.ErrorIs(t, err, ErrFileType)
+
+	entries, err := os.ReadDir(dir)
+	must.NoError(t, err)
+	must.SliceEmpty(t, entries)
+}
+
+func Test_Parse_CopyTo(t *testing.T) {
+	t.Parallel()
+
+	r := postMultipart(t, nil,
+		upload{field: "doc", filename: "notes.txt", content: []byte("hello")},
+	)
+
+	var (
+		buf    bytes.Buffer
+		stored StoredFile
+	)
+	err := Parse(r, Schema{
+		"doc": CopyTo(&buf, &stored),
+	})
+	must.NoError(t, err)
+	must.Eq(t, "hello", buf.String())
+	must.Eq(t, StoredFile{Filename: "notes.txt", Size: 5, SHA256: checksum([]byte("hello"))}, stored)
+}
+
+func Test_extension(t *testing.T) {
+	t.Parallel()
+
+	must.Eq(t, ".png", extension("cat.png"))
+	must.Eq(t, ".gz", extension("a/b/archive.tar.gz"))
+	must.Eq(t, "", extension("noext"))
+	must.Eq(t, "", extension("bad.p*g"))
+	must.Eq(t, "", extension("evil.png/.."))
+}
@@ -0,0 +1,209 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	nonceSize   = 16
+	payloadSize = nonceSize + 8
+)
+
+// A TokenStore records which form tokens have been used, so that each token
+// is accepted only once.
+type TokenStore interface {
+	// Use marks the token identified by id as used. The token is no longer
+	// valid after expires, so the store need not remember it beyond then. If
+	// the token was already used, Use must return ErrTokenUsed.
+	Use(ctx context.Context, id string, expires time.Time) error
+}
+
+// Tokens generates and verifies signed, expiring form tokens, which are
+// embedded in a hidden field of a form and checked by the FormToken parser
+// when the form is submitted. With a TokenStore, each token is accepted only
+// once, preventing duplicate submissions from double clicks and replays.
+type Tokens struct {
+	key   []byte
+	ttl   time.Duration
+	store TokenStore
+	now   func() time.Time
+}
+
+// NewTokens creates a Tokens which signs tokens with key, a secret of at least
+// 32 random bytes, and which remain valid for ttl after being generated. If
+// store is nil, tokens may be used more than once before they expire.
+func NewTokens(key []byte, ttl time.Duration, store TokenStore) *Tokens {
+	return &Tokens{
+		key:   key,
+		ttl:   ttl,
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Generate creates a new token to embed in a form.
+func (t *Tokens) Generate() (string, error) {
+	payload := make([]byte, payloadSize)
+	if _, err := rand.Read(payload[:nonceSize]); err != nil {
+		return "", err
+	}
+	expires := t.now().Add(t.ttl).Unix()
+	binary.BigEndian.PutUint64(payload[nonceSize:], uint64(expires))
+
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(t.sign(payload)), nil
+}
+
+func (t *Tokens) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, t.key)
+	_, _ = mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// verify checks the signature and expiration of token, and then marks it as
+// used in the store.
+func (t *Tokens) verify(ctx context.Context, token string) error {
+	id, expires, err := t.check(token)
+	if err != nil {
+		return err
+	}
+	return t.use(ctx, id, expires)
+}
+
+// check verifies the signature and expiration of token, returning the id by
+// which it is recorded in the store.
+func (t *Tokens) check(token string) (string, time.Time, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", time.Time{}, ErrInvalidToken
+	}
+
+	enc := base64.RawURLEncoding
+	payload, err := enc.DecodeString(encoded)
+	if err != nil || len(payload) != payloadSize {
+		return "", time.Time{}, ErrInvalidToken
+	}
+	mac, err := enc.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, t.sign(payload)) {
+		return "", time.Time{}, ErrInvalidToken
+	}
+
+	expires := time.Unix(int64(binary.BigEndian.Uint64(payload[nonceSize:])), 0)
+	if !t.now().Before(expires) {
+		return "", time.Time{}, ErrTokenExpired
+	}
+	return encoded, expires, nil
+}
+
+// use marks the token id as used in the store, if there is one.
+func (t *Tokens) use(ctx context.Context, id string, expires time.Time) error {
+	if t.store == nil {
+		return nil
+	}
+	return t.store.Use(ctx, id, expires)
+}
+
+type tokenParser struct {
+	tokens *Tokens
+}
+
+func (p *tokenParser) describe() field {
+	return field{kind: "form token", required: true, sensitive: true}
+}
+
+func (p *tokenParser) Parse(values []string) error {
+	return p.ParseContext(context.Background(), values)
+}
+
+func (p *tokenParser) ParseContext(ctx context.Context, values []string) error {
+	switch {
+	case len(values) == 0:
+		return ErrNoValue
+	case len(values) > 1:
+		return ErrMulitpleValues
+	}
+	return p.tokens.verify(ctx, values[0])
+}
+
+// parseForm checks the token while the form is parsed, but marks it as used
+// only once every other field has parsed, so that a form which fails can be
+// corrected and submitted again with the same token.
+func (p *tokenParser) parseForm(_ context.Context, name string, data url.Values, o *options) error {
+	values := data[name]
+	switch {
+	case len(values) == 0:
+		return ErrNoValue
+	case len(values) > 1:
+		return ErrMulitpleValues
+	}
+
+	id, expires, err := p.tokens.check(values[0])
+	if err != nil {
+		return err
+	}
+	o.later(name, func(ctx context.Context) error {
+		return p.tokens.use(ctx, id, expires)
+	})
+	return nil
+}
+
+func (p *tokenParser) claims(name, key string) bool {
+	return key == name
+}
+
+// FormToken is used to extract a form token generated by tokens, checking its
+// signature, its expiration, and (if tokens has a TokenStore) that it has not
+// been used before. If the token fails any check then ErrInvalidToken,
+// ErrTokenExpired, or ErrTokenUsed is returned during parsing. The token is
+// marked as used only if every field of the form parses successfully.
+func FormToken(tokens *Tokens) Parser {
+	return &tokenParser{
+		tokens: tokens,
+	}
+}
+
+type memoryTokenStore struct {
+	lock sync.Mutex
+	used map[string]time.Time
+	now  func() time.Time
+}
+
+// NewMemoryTokenStore creates a TokenStore which records used tokens in memory.
+// It is suitable for a single process; deployments with many replicas need a
+// shared store.
+func NewMemoryTokenStore() TokenStore {
+	return &memoryTokenStore{
+		used: make(map[string]time.Time),
+		now:  time.Now,
+	}
+}
+
+func (s *memoryTokenStore) Use(_ context.Context, id string, expires time.Time) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	for used, until := range s.used {
+		if !now.Before(until) {
+			delete(s.used, used)
+		}
+	}
+
+	if _, exists := s.used[id]; exists {
+		return ErrTokenUsed
+	}
+	s.used[id] = expires
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+)
+
+var testTokenKey = []byte("0123456789abcdef0123456789abcdef")
+
+func Test_FormToken(t *testing.T) {
+	t.Parallel()
+
+	tokens := NewTokens(testTokenKey, time.Hour, NewMemoryTokenStore())
+	token, err := tokens.Generate()
+	must.NoError(t, err)
+
+	schema := Schema{"token": FormToken(tokens)}
+
+	err = ParseValues(url.Values{"token": {token}}, schema)
+	must.NoError(t, err)
+
+	// a second submission of the same token is rejected
+	err = ParseValues(url.Values{"token": {token}}, schema)
+	must.ErrorIs(t, err, ErrTokenUsed)
+}
+
+func Test_FormToken_failed_form(t *testing.T) {
+	t.Parallel()
+
+	tokens := NewTokens(testTokenKey, time.Hour, NewMemoryTokenStore())
+	token, err := tokens.Generate()
+	must.NoError(t, err)
+
+	// fields sorting both before and after the token
+	var a, zip string
+	schema := Schema{
+		"a":     String(&a),
+		"token": FormToken(tokens),
+		"zip":   String(&zip),
+	}
+
+	// a form which fails does not use the token, in either error mode
+	err = ParseValues(url.Values{"a": {"1"}, "token": {token}}, schema)
+	must.ErrorIs(t, err, ErrNoValue)
+	err = ParseValues(url.Values{"zip": {"1"}, "token": {token}}, schema, CollectAllErrors())
+	must.ErrorIs(t, err, ErrNoValue)
+
+	err = ParseValues(url.Values{"a": {"1"}, "zip": {"2"}, "token": {token}}, schema)
+	must.NoError(t, err)
+
+	err = ParseValues(url.Values{"a": {"1"}, "zip": {"2"}, "token": {token}}, schema)
+	must.ErrorIs(t, err, ErrTokenUsed)
+	must.EqError(t, err, "could not parse value: token: form token has already been used")
+}
+
+func Test_FormToken_Group(t *testing.T) {
+	t.Parallel()
+
+	tokens := NewTokens(testTokenKey, time.Hour, NewMemoryTokenStore())
+	token, err := tokens.Generate()
+	must.NoError(t, err)
+
+	schema := Schema{"user": Group(Schema{"token": FormToken(tokens)})}
+	err = ParseValues(url.Values{"user[token]": {token}}, schema)
+	must.NoError(t, err)
+
+	err = ParseValues(url.Values{"user[token]": {token}}, schema)
+	must.ErrorIs(t, err, ErrTokenUsed)
+	var fe *FieldError
+	must.True(t, errors.As(err, &fe))
+	must.Eq(t, "user[token]", fe.Field)
+}
+
+func Test_FormToken_no_store(t *testing.T) {
+	t.Parallel()
+
+	tokens := NewTokens(testTokenKey, time.Hour, nil)
+	token, err := tokens.Generate()
+	must.NoError(t, err)
+
+	for range 2 {
+		err = ParseValues(url.Values{"token": {token}}, Schema{"token": FormToken(tokens)})
+		must.NoError(t, err)
+	}
+}
+
+func Test_FormToken_expired(t *testing.T) {
+	t.Parallel()
+
+	tokens := NewTokens(testTokenKey, time.Minute, nil)
+	token, err := tokens.Generate()
+	must.NoError(t, err)
+
+	tokens.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	err = ParseValues(url.Values{"token": {token}}, Schema{"token": FormToken(tokens)})
+	must.ErrorIs(t, err, ErrTokenExpired)
+}
+
+func Test_FormToken_invalid(t *testing.T) {
+	t.Parallel()
+
+	tokens := NewTokens(testTokenKey, time.Hour, nil)
+	other := NewTokens([]byte("another key entirely, 32 bytes!!"), time.Hour, nil)
+
+	forged, err := other.Generate()
+	must.NoError(t, err)
+
+	valid, err := tokens.Generate()
+	must.NoError(t, err)
+
+	for _, token := range []string{forged, "garbage", "a.b", valid[:10] + "." + valid[11:], valid + "x"} {
+		err = ParseValues(url.Values{"token": {token}}, Schema{"token": FormToken(tokens)})
+		must.ErrorIs(t, err, ErrInvalidToken, must.Sprint(token))
+	}
+
+	err = ParseValues(url.Values{}, Schema{"token": FormToken(tokens)})
+	must.ErrorIs(t, err, ErrNoValue)
+}
@@ -0,0 +1,238 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A converter produces a value of some type from a single form value.
+type converter func(string) (reflect.Value, error)
+
+// conversions maps a reflect.Type to the converter registered for it.
+var conversions sync.Map
+
+// RegisterType records fn as the way of converting a form value into a T,
+// for use by Any and Bind. This allows types from other packages, such as
+// UUIDs or decimals, to be used as destinations throughout a program without
+// writing a Parser for each. Registering a type again replaces its function.
+func RegisterType[T any](fn func(string) (T, error)) {
+	conversions.Store(reflect.TypeFor[T](), converter(func(value string) (reflect.Value, error) {
+		v, err := fn(value)
+		return reflect.ValueOf(&v).Elem(), err
+	}))
+}
+
+var textUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// converterFor returns the converter for values of type t, preferring a
+// registered function, then encoding.TextUnmarshaler, then the built-in kinds.
+func converterFor(t reflect.Type) (converter, bool) {
+	if c, ok := conversions.Load(t); ok {
+		return c.(converter), true
+	}
+
+	if reflect.PointerTo(t).Implements(textUnmarshaler) {
+		return func(value string) (reflect.Value, error) {
+			v := reflect.New(t)
+			err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+			return v.Elem(), err
+		}, true
+	}
+
+	convert := func(fn func(string, reflect.Value) error) converter {
+		return func(value string) (reflect.Value, error) {
+			v := reflect.New(t).Elem()
+			return v, fn(value, v)
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return convert(func(value string, v reflect.Value) error {
+			v.SetString(value)
+			return nil
+		}), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return convert(func(value string, v reflect.Value) error {
+			i, err := strconv.ParseInt(value, 10, t.Bits())
+			v.SetInt(i)
+			return err
+		}), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return convert(func(value string, v reflect.Value) error {
+			u, err := strconv.ParseUint(value, 10, t.Bits())
+			v.SetUint(u)
+			return err
+		}), true
+	case reflect.Float32, reflect.Float64:
+		return convert(func(value string, v reflect.Value) error {
+			f, err := strconv.ParseFloat(value, t.Bits())
+			v.SetFloat(f)
+			return err
+		}), true
+	case reflect.Bool:
+		return convert(func(value string, v reflect.Value) error {
+			var b bool
+			err := Bool(&b).Parse([]string{value})
+			v.SetBool(b)
+			return err
+		}), true
+	}
+	return nil, false
+}
+
+type anyParser struct {
+	required    bool
+	multiple    bool
+	convert     converter
+	alt         any
+	target      reflect.Value // the element pointed to by destination
+	destination any
+}
+
+func (p *anyParser) describe() field {
+	f := field{
+		kind:     p.target.Type().String(),
+		required: p.required,
+		multiple: p.multiple,
+	}
+	if !p.required {
+		f.alt = p.alt
+	}
+	return f
+}
+
+func (p *anyParser) destinations() []any {
+	return []any{p.destination}
+}
+
+func (p *anyParser) Parse(values []string) error {
+	switch {
+	case len(values) > 1 && !p.multiple:
+		return ErrMulitpleValues
+	case len(values) == 0 && p.required:
+		return ErrNoValue
+	case len(values) == 0:
+		return nil
+	}
+
+	if !p.multiple {
+		v, err := p.convert(values[0])
+		if err != nil {
+			return err
+		}
+		p.target.Set(v)
+		return nil
+	}
+
+	s := reflect.MakeSlice(p.target.Type(), 0, len(values))
+	for _, value := range values {
+		v, err := p.convert(value)
+		if err != nil {
+			return err
+		}
+		s = reflect.Append(s, v)
+	}
+	p.target.Set(s)
+	return nil
+}
+
+// newAnyParser creates the Parser for destination, which must be a non-nil
+// pointer to a type with a converter, or a slice of such a type.
+func newAnyParser(destination any, required bool) (*anyParser, error) {
+	rv := reflect.ValueOf(destination)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return nil, fmt.Errorf("%w: %T is not a non-nil pointer", ErrUnsupportedType, destination)
+	}
+
+	target := rv.Elem()
+	t, multiple := target.Type(), false
+	convert, ok := converterFor(t)
+	if !ok && t.Kind() == reflect.Slice {
+		convert, ok = converterFor(t.Elem())
+		multiple = true
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+	}
+
+	return &anyParser{
+		required:    required,
+		multiple:    multiple,
+		convert:     convert,
+		alt:         target.Interface(),
+		target:      target,
+		destination: destination,
+	}, nil
+}
+
+type unsupportedParser struct {
+	err error
+}
+
+func (p *unsupportedParser) Parse([]string) error {
+	return p.err
+}
+
+// Any is used to extract a form data value into the Go value pointed to by v,
+// using the function registered for its type with RegisterType. Types without
+// a registered function are supported if they implement
+// encoding.TextUnmarshaler, or are a string, integer, float, or bool type. A
+// slice of a supported type receives every value of the field. If the value
+// is missing or cannot be converted then an error is returned during parsing,
+// as it is for every parse if the type of v is not supported.
+func Any(v any) Parser {
+	p, err := newAnyParser(v, true)
+	if err != nil {
+		return &unsupportedParser{err: err}
+	}
+	return p
+}
+
+// Bind creates a Schema for the struct pointed to by dst from the form tags of
+// its exported fields, in the same format used by the forms-gen command:
+//
+//	Name  string `form:"name"`
+//	Email string `form:"email,optional"`
+//
+// A field with an empty tag name is bound to the name of the field, and a tag
+// of "-" is ignored. An optional field keeps its current value if the form
+// value is missing. Each field is parsed as by Any, and an error is returned
+// if dst is not a pointer to a struct or a field has an unsupported type.
+func Bind(dst any) (Schema, error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T is not a pointer to a struct", ErrUnsupportedType, dst)
+	}
+	rv = rv.Elem()
+
+	schema := make(Schema)
+	for _, sf := range reflect.VisibleFields(rv.Type()) {
+		tag, ok := sf.Tag.Lookup("form")
+		if !ok || tag == "-" || !sf.IsExported() || len(sf.Index) > 1 {
+			continue
+		}
+
+		name, option, _ := strings.Cut(tag, ",")
+		if option != "" && option != "optional" {
+			return nil, fmt.Errorf("%w: field %s has unknown form tag option %q", ErrInvalidSchema, sf.Name, option)
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		p, err := newAnyParser(rv.FieldByIndex(sf.Index).Addr().Interface(), option != "optional")
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+		schema[name] = p
+	}
+	return schema, nil
+}
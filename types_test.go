@@ -0,0 +1,159 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+)
+
+// sku is a third-party style type, registered by the tests which use it.
+type sku struct {
+	vendor, item string
+}
+
+var skuOnce sync.Once
+
+func registerSKU() {
+	skuOnce.Do(func() {
+		RegisterType(func(value string) (sku, error) {
+			vendor, item, ok := strings.Cut(value, "/")
+			if !ok {
+				return sku{}, errors.New("sku must be vendor/item")
+			}
+			return sku{vendor: vendor, item: item}, nil
+		})
+	})
+}
+
+func Test_Parse_Any(t *testing.T) {
+	t.Parallel()
+	registerSKU()
+
+	var (
+		product sku
+		tier    plan
+		count   uint16
+		ratio   float32
+		agree   bool
+		ip      net.IP
+		when    time.Time
+		tags    []string
+		skus    []sku
+	)
+
+	err := ParseValues(url.Values{
+		"product": {"acme/anvil"},
+		"tier":    {"pro"},
+		"count":   {"12"},
+		"ratio":   {"0.25"},
+		"agree":   {"on"},
+		"ip":      {"10.0.0.1"},
+		"when":    {"2026-01-02T03:04:05Z"},
+		"tags":    {"a", "b"},
+		"skus":    {"acme/a", "acme/b"},
+	}, Schema{
+		"product": Any(&product),
+		"tier":    Any(&tier),
+		"count":   Any(&count),
+		"ratio":   Any(&ratio),
+		"agree":   Any(&agree),
+		"ip":      Any(&ip),
+		"when":    Any(&when),
+		"tags":    Any(&tags),
+		"skus":    Any(&skus),
+	})
+	must.NoError(t, err)
+	must.Eq(t, sku{vendor: "acme", item: "anvil"}, product)
+	must.Eq(t, planPro, tier)
+	must.Eq(t, 12, count)
+	must.Eq(t, 0.25, ratio)
+	must.True(t, agree)
+	must.Eq(t, "10.0.0.1", ip.String())
+	must.Eq(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), when)
+	must.Eq(t, []string{"a", "b"}, tags)
+	must.Eq(t, []sku{{"acme", "a"}, {"acme", "b"}}, skus)
+}
+
+func Test_Parse_Any_invalid(t *testing.T) {
+	t.Parallel()
+	registerSKU()
+
+	var (
+		product sku
+		count   uint8
+		ch      chan int
+	)
+
+	err := ParseValues(url.Values{"product": {"anvil"}}, Schema{"product": Any(&product)})
+	must.EqError(t, err, "could not parse value: product: sku must be vendor/item")
+
+	err = ParseValues(url.Values{"count": {"300"}}, Schema{"count": Any(&count)})
+	must.Error(t, err)
+
+	err = ParseValues(url.Values{}, Schema{"count": Any(&count)})
+	must.ErrorIs(t, err, ErrNoValue)
+
+	err = ParseValues(url.Values{"ch": {"1"}}, Schema{"ch": Any(&ch)})
+	must.ErrorIs(t, err, ErrUnsupportedType)
+
+	err = ParseValues(url.Values{"count": {"1"}}, Schema{"count": Any(count)})
+	must.ErrorIs(t, err, ErrUnsupportedType)
+}
+
+type bindForm struct {
+	Name     string   `form:"name"`
+	Email    string   `form:"email,optional"`
+	Product  sku      `form:"product"`
+	Tags     []string `form:",optional"`
+	Ignored  string   `form:"-"`
+	Untagged string
+}
+
+func Test_Bind(t *testing.T) {
+	t.Parallel()
+	registerSKU()
+
+	form := bindForm{Email: "none@example.com"}
+	schema, err := Bind(&form)
+	must.NoError(t, err)
+	must.MapLen(t, 4, schema)
+	must.NoError(t, schema.Check())
+
+	err = ParseValues(url.Values{"name": {"bob"}, "product": {"acme/anvil"}, "Tags": {"x"}}, schema, Strict())
+	must.NoError(t, err)
+	must.Eq(t, bindForm{
+		Name:    "bob",
+		Email:   "none@example.com",
+		Product: sku{vendor: "acme", item: "anvil"},
+		Tags:    []string{"x"},
+	}, form)
+
+	err = ParseValues(url.Values{"product": {"acme/anvil"}}, schema)
+	must.ErrorIs(t, err, ErrNoValue)
+}
+
+func Test_Bind_invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := Bind(bindForm{})
+	must.ErrorIs(t, err, ErrUnsupportedType)
+
+	_, err = Bind(&struct {
+		C chan int `form:"c"`
+	}{})
+	must.ErrorIs(t, err, ErrUnsupportedType)
+
+	_, err = Bind(&struct {
+		S string `form:"s,required"`
+	}{})
+	must.ErrorIs(t, err, ErrInvalidSchema)
+}
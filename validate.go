@@ -0,0 +1,136 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// A StructValidator validates a struct after a form has been parsed into it.
+// The *Validate of github.com/go-playground/validator satisfies this interface,
+// allowing its tag rules to be checked on top of parsing.
+type StructValidator interface {
+	Struct(any) error
+}
+
+// WithValidator causes a value parsed from a form by a Definition to be
+// validated by v, once every field of the form has parsed successfully.
+//
+// If v returns a collection of errors, such as the ValidationErrors of
+// github.com/go-playground/validator, each error which describes a struct
+// field (by a StructNamespace method) is reported as a FieldError of the form
+// field with that destination. Otherwise the error is reported for the form
+// as a whole. WithValidator has no effect on parsing a Schema.
+func WithValidator(v StructValidator) Option {
+	return func(o *options) {
+		o.validator = v
+	}
+}
+
+// validateStruct validates form against o.validator, if any, reporting errors
+// of struct fields as errors of the fields of schema with the same destination.
+func validateStruct(form any, schema Schema, o *options) error {
+	if o.validator == nil {
+		return nil
+	}
+
+	err := o.validator.Struct(form)
+	if err == nil {
+		return nil
+	}
+
+	names := make(map[any]string)
+	for name, p := range schema {
+		for _, d := range destinations(p) {
+			if reflect.ValueOf(d).Kind() == reflect.Pointer {
+				names[d] = name
+			}
+		}
+	}
+
+	var errs Errors
+	for _, e := range validationErrors(err) {
+		namespaced, ok := e.(interface{ StructNamespace() string })
+		if !ok {
+			return fmt.Errorf("%s: %w: %w", ErrParseFailure.Error(), ErrValidation, err)
+		}
+		field := structField(form, namespaced.StructNamespace())
+		name, ok := names[field]
+		if !ok {
+			// the struct field is not set by the schema, so it is reported
+			// by its path within the struct
+			_, name, _ = strings.Cut(namespaced.StructNamespace(), ".")
+		}
+		errs = append(errs, &FieldError{Field: name, Err: validationError(e)})
+	}
+
+	slices.SortStableFunc(errs, func(a, b *FieldError) int {
+		return strings.Compare(a.Field, b.Field)
+	})
+	if !o.collect {
+		return errs[:1]
+	}
+	return errs
+}
+
+// validationErrors returns the errors of err, which may be a slice of errors
+// (as with go-playground/validator), an error with an Unwrap() []error method,
+// or a single error.
+func validationErrors(err error) []error {
+	if multi, ok := err.(interface{ Unwrap() []error }); ok {
+		if errs := multi.Unwrap(); len(errs) > 0 {
+			return errs
+		}
+	}
+
+	v := reflect.ValueOf(err)
+	if v.Kind() != reflect.Slice || v.Len() == 0 {
+		return []error{err}
+	}
+	errs := make([]error, 0, v.Len())
+	for i := range v.Len() {
+		e, ok := v.Index(i).Interface().(error)
+		if !ok {
+			return []error{err}
+		}
+		errs = append(errs, e)
+	}
+	return errs
+}
+
+// validationError describes e by the tag which failed, if e has a Tag method,
+// or by its message otherwise.
+func validationError(e error) error {
+	if tagged, ok := e.(interface{ Tag() string }); ok {
+		return fmt.Errorf("%w: %s", ErrValidation, tagged.Tag())
+	}
+	return fmt.Errorf("%w: %w", ErrValidation, e)
+}
+
+// structField returns a pointer to the field of form at namespace, being the
+// type name followed by dotted field names, e.g. "Signup.Address.City". It
+// returns nil if there is no such field.
+func structField(form any, namespace string) any {
+	v := reflect.ValueOf(form)
+	path := strings.Split(namespace, ".")
+	for _, name := range path[1:] {
+		for v.Kind() == reflect.Pointer && !v.IsNil() {
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return nil
+		}
+		v = v.FieldByName(name)
+		if !v.IsValid() {
+			return nil
+		}
+	}
+	if len(path) < 2 || !v.CanAddr() {
+		return nil
+	}
+	return v.Addr().Interface()
+}
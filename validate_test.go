@@ -0,0 +1,95 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+// tagError and tagErrors mimic the FieldError and ValidationErrors of
+// go-playground/validator.
+type tagError struct {
+	namespace string
+	tag       string
+}
+
+func (e tagError) Error() string           { return e.namespace + " failed on " + e.tag }
+func (e tagError) StructNamespace() string { return e.namespace }
+func (e tagError) Tag() string             { return e.tag }
+
+type tagErrors []tagError
+
+func (e tagErrors) Error() string { return "validation failed" }
+
+// signupValidator requires a name of at least 3 characters and an age of at
+// least 21.
+type signupValidator struct{}
+
+func (signupValidator) Struct(v any) error {
+	s := v.(*signup)
+	var errs tagErrors
+	if s.Age < 21 {
+		errs = append(errs, tagError{namespace: "signup.Age", tag: "gte"})
+	}
+	if len(s.Name) < 3 {
+		errs = append(errs, tagError{namespace: "signup.Name", tag: "min"})
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+type structValidator func(any) error
+
+func (f structValidator) Struct(v any) error { return f(v) }
+
+func Test_WithValidator(t *testing.T) {
+	t.Parallel()
+
+	form, err := signupDefinition.ParseValues(url.Values{
+		"name": {"bob"},
+		"age":  {"30"},
+	}, WithValidator(signupValidator{}))
+	must.NoError(t, err)
+	must.Eq(t, &signup{Name: "bob", Age: 30}, form)
+
+	_, err = signupDefinition.ParseValues(url.Values{"name": {"bob"}}, WithValidator(signupValidator{}))
+	must.ErrorIs(t, err, ErrValidation)
+	must.EqError(t, err, "could not parse value: age: failed validation: gte")
+
+	_, err = signupDefinition.ParseValues(url.Values{"name": {"al"}}, WithValidator(signupValidator{}), CollectAllErrors())
+	var errs Errors
+	must.True(t, errors.As(err, &errs))
+	must.SliceLen(t, 2, errs)
+	must.Eq(t, "age", errs[0].Field)
+	must.Eq(t, "name", errs[1].Field)
+
+	// parse errors are reported without validating
+	_, err = signupDefinition.ParseValues(url.Values{}, WithValidator(signupValidator{}))
+	must.ErrorIs(t, err, ErrNoValue)
+	must.False(t, errors.Is(err, ErrValidation))
+}
+
+func Test_WithValidator_unmapped(t *testing.T) {
+	t.Parallel()
+
+	sentinel := errors.New("accounts are closed")
+	_, err := signupDefinition.ParseValues(url.Values{"name": {"bob"}}, WithValidator(structValidator(func(any) error {
+		return sentinel
+	})))
+	must.ErrorIs(t, err, ErrValidation)
+	must.ErrorIs(t, err, sentinel)
+
+	_, err = signupDefinition.ParseValues(url.Values{"name": {"bob"}}, WithValidator(structValidator(func(any) error {
+		return tagError{namespace: "signup.Extra.Field", tag: "required"}
+	})))
+	var fe *FieldError
+	must.True(t, errors.As(err, &fe))
+	must.Eq(t, "Extra.Field", fe.Field)
+}
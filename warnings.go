@@ -0,0 +1,169 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/url"
+)
+
+// A Warning is an advisory produced while parsing a field, such as the use of
+// a deprecated field or the truncation of a value. Unlike an error, a Warning
+// does not cause parsing to fail; warnings are gathered with CollectWarnings.
+type Warning struct {
+	Field   string
+	Message string
+}
+
+func (w *Warning) Error() string {
+	if w.Field == "" {
+		return w.Message
+	}
+	return w.Field + ": " + w.Message
+}
+
+// Warn returns a Warning with message, for a Parser to return once it has
+// successfully written its destination but has an advisory to report.
+func Warn(message string) error {
+	return &Warning{Message: message}
+}
+
+func asWarning(err error) (*Warning, bool) {
+	var w *Warning
+	if errors.As(err, &w) {
+		return w, true
+	}
+	return nil, false
+}
+
+type deprecatedParser struct {
+	Parser
+	message string
+}
+
+func (p *deprecatedParser) describe() field {
+	f := describe(p.Parser)
+	f.constraints = append(f.constraints[:len(f.constraints):len(f.constraints)], "deprecated: "+p.message)
+	return f
+}
+
+func (p *deprecatedParser) destinations() []any {
+	return destinations(p.Parser)
+}
+
+func (p *deprecatedParser) parseForm(ctx context.Context, name string, data url.Values, o *options) error {
+	if err := parseField(ctx, name, p.Parser, data, o); err != nil {
+		return err
+	}
+	if p.claimed(name, data) {
+		return Warn(p.message)
+	}
+	return nil
+}
+
+func (p *deprecatedParser) claims(name, key string) bool {
+	if fp, ok := p.Parser.(formParser); ok {
+		return fp.claims(name, key)
+	}
+	return key == name
+}
+
+// claimed reports whether data contains any value read by the parser.
+func (p *deprecatedParser) claimed(name string, data url.Values) bool {
+	for key, values := range data {
+		if len(values) > 0 && p.claims(name, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Deprecated wraps p so that a Warning with message is produced whenever the
+// field is submitted, e.g. to track clients still using a field which is
+// being removed.
+func Deprecated(p Parser, message string) Parser {
+	return &deprecatedParser{
+		Parser:  p,
+		message: message,
+	}
+}
+
+type truncateParser struct {
+	Parser
+	limit int
+}
+
+func (p *truncateParser) describe() field {
+	return describe(p.Parser)
+}
+
+func (p *truncateParser) destinations() []any {
+	return destinations(p.Parser)
+}
+
+func (p *truncateParser) parseForm(ctx context.Context, name string, data url.Values, o *options) error {
+	values, truncated := truncate(data[name], p.limit)
+
+	view := url.Values{name: values}
+	if _, ok := p.Parser.(formParser); ok {
+		view = maps.Clone(data)
+		view[name] = values
+	}
+
+	if err := parseField(ctx, name, p.Parser, view, o); err != nil {
+		return err
+	}
+	if truncated {
+		return Warn("value was truncated")
+	}
+	return nil
+}
+
+func (p *truncateParser) claims(name, key string) bool {
+	if fp, ok := p.Parser.(formParser); ok {
+		return fp.claims(name, key)
+	}
+	return key == name
+}
+
+// truncate shortens each of values to at most limit characters, reporting
+// whether any value was shortened.
+func truncate(values []string, limit int) ([]string, bool) {
+	var shortened []string
+	for i, value := range values {
+		if len(value) <= limit {
+			continue
+		}
+		cut, count := len(value), 0
+		for j := range value {
+			if count == limit {
+				cut = j
+				break
+			}
+			count++
+		}
+		if cut == len(value) {
+			continue
+		}
+		if shortened == nil {
+			shortened = append([]string(nil), values...)
+		}
+		shortened[i] = value[:cut]
+	}
+	if shortened == nil {
+		return values, false
+	}
+	return shortened, true
+}
+
+// Truncate wraps p so that values longer than limit characters are shortened
+// to limit characters before being parsed, producing a Warning when they are.
+func Truncate(p Parser, limit int) Parser {
+	return &truncateParser{
+		Parser: p,
+		limit:  limit,
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) CattleCloud LLC
+// SPDX-License-Identifier: BSD-3-Clause
+
+package forms
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_Parse_Deprecated(t *testing.T) {
+	t.Parallel()
+
+	var (
+		warnings []Warning
+		fax      string
+		phone    string
+	)
+
+	err := ParseValues(url.Values{"fax": {"555-1234"}, "phone": {"555-9876"}}, Schema{
+		"fax":   Deprecated(StringOr(&fax, ""), "fax numbers are no longer used"),
+		"phone": String(&phone),
+	}, CollectWarnings(&warnings))
+	must.NoError(t, err)
+	must.Eq(t, "555-1234", fax)
+	must.Eq(t, []Warning{{Field: "fax", Message: "fax numbers are no longer used"}}, warnings)
+
+	warnings = nil
+	err = ParseValues(url.Values{"phone": {"555-9876"}}, Schema{
+		"fax":   Deprecated(StringOr(&fax, ""), "fax numbers are no longer used"),
+		"phone": String(&phone),
+	}, CollectWarnings(&warnings))
+	must.NoError(t, err)
+	must.SliceEmpty(t, warnings)
+}
+
+func Test_Parse_Deprecated_error(t *testing.T) {
+	t.Parallel()
+
+	var age int
+	err := ParseValues(url.Values{"age": {"old"}}, Schema{
+		"age": Deprecated(Int(&age), "use birthday"),
+	})
+	must.Error(t, err)
+}
+
+func Test_Parse_Truncate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		warnings []Warning
+		bio      string
+		title    string
+	)
+
+	err := ParseValues(url.Values{"bio": {"héllo world"}, "title": {"short"}}, Schema{
+		"bio":   Truncate(String(&bio), 5),
+		"title": Truncate(String(&title), 10),
+	}, CollectWarnings(&warnings))
+	must.NoError(t, err)
+	must.Eq(t, "héllo", bio)
+	must.Eq(t, "short", title)
+	must.Eq(t, []Warning{{Field: "bio", Message: "value was truncated"}}, warnings)
+}
+
+func Test_Parse_warnings_discarded(t *testing.T) {
+	t.Parallel()
+
+	var bio string
+	err := ParseValues(url.Values{"bio": {"too long"}}, Schema{
+		"bio": Truncate(String(&bio), 3),
+	})
+	must.NoError(t, err)
+	must.Eq(t, "too", bio)
+
+	res := CollectValues(url.Values{"bio": {"too long"}})
+	res.parse("bio", Truncate(String(&bio), 3))
+	must.NoError(t, res.Err())
+}
+
+func Test_Warning_Error(t *testing.T) {
+	t.Parallel()
+
+	must.EqError(t, Warn("careful"), "careful")
+	must.EqError(t, &Warning{Field: "x", Message: "careful"}, "x: careful")
+}